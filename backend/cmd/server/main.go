@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/gin-contrib/cors"
@@ -11,10 +12,15 @@ import (
 	"github.com/hdu-dp/backend/internal/handlers"
 	adminHandlers "github.com/hdu-dp/backend/internal/handlers/admin"
 	"github.com/hdu-dp/backend/internal/middleware"
+	"github.com/hdu-dp/backend/internal/models"
+	"github.com/hdu-dp/backend/internal/moderation"
+	"github.com/hdu-dp/backend/internal/realtime"
 	"github.com/hdu-dp/backend/internal/repository"
 	"github.com/hdu-dp/backend/internal/router"
 	"github.com/hdu-dp/backend/internal/services"
 	"github.com/hdu-dp/backend/internal/storage"
+	"github.com/hdu-dp/backend/internal/textfilter"
+	"github.com/hdu-dp/backend/internal/workflow"
 )
 
 // @title           杭电点评 API
@@ -52,7 +58,17 @@ func main() {
 
 	userRepo := repository.NewUserRepository(db)
 	reviewRepo := repository.NewReviewRepository(db)
+	placeRepo := repository.NewPlaceRepository(db)
 	refreshRepo := repository.NewRefreshTokenRepository(db)
+	moderationActionRepo := repository.NewModerationActionRepository(db)
+	rejectionReasonRepo := repository.NewRejectionReasonRepository(db)
+	sensitiveWordRepo := repository.NewSensitiveWordRepository(db)
+	reviewReportRepo := repository.NewReviewReportRepository(db)
+	commentRepo := repository.NewCommentRepository(db)
+	reviewVoteRepo := repository.NewReviewVoteRepository(db)
+	favoriteRepo := repository.NewFavoriteRepository(db)
+	followRepo := repository.NewFollowRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
 
 	storageProvider, err := storage.New(cfg)
 	if err != nil {
@@ -62,12 +78,72 @@ func main() {
 	jwtManager := auth.NewJWTManager(cfg.Auth.JWTSecret, cfg.Auth.AccessTokenTTL)
 
 	authService := services.NewAuthService(userRepo, jwtManager, refreshRepo, cfg.Auth.RefreshTokenTTL)
-	reviewService := services.NewReviewService(reviewRepo, storageProvider)
+	ratingPrior := models.BayesianPrior{Mean: cfg.Rating.PriorMean, Weight: cfg.Rating.PriorWeight}
+
+	reviewMachine := workflow.NewReviewMachine()
+	contentFilter := textfilter.NewFilter()
+	var moderationPipeline, commentPipeline *moderation.Pipeline
+	if cfg.Moderation.Enabled {
+		moderationPipeline = moderation.NewPipeline(
+			moderation.Policy{ApproveBelow: cfg.Moderation.AutoApproveBelow, RejectAt: cfg.Moderation.AutoRejectAt},
+			moderation.NewWordCheck(contentFilter),
+			moderation.NewLinkCheck(),
+			moderation.NewDuplicateCheck(),
+			moderation.NewReputationCheck(reviewRepo),
+		)
+		// Comments have no fingerprints and the reputation check scores
+		// review authors, so only the text checks apply to them.
+		commentPipeline = moderation.NewPipeline(
+			moderation.Policy{ApproveBelow: cfg.Moderation.CommentAutoApproveBelow, RejectAt: cfg.Moderation.CommentAutoRejectAt},
+			moderation.NewWordCheck(contentFilter),
+			moderation.NewLinkCheck(),
+		)
+	}
+
+	reviewService := services.NewReviewService(reviewRepo, placeRepo, storageProvider, ratingPrior, reviewMachine, moderationActionRepo, rejectionReasonRepo, reviewReportRepo, contentFilter, moderationPipeline, services.DuplicatePolicy{
+		MaxDistance: cfg.Review.DuplicateDistance,
+		BlockExact:  cfg.Review.BlockDuplicates,
+	}, cfg.Review.ClaimTTL)
+	placeService := services.NewPlaceService(placeRepo, reviewRepo, ratingPrior)
+	rejectionReasonService := services.NewRejectionReasonService(rejectionReasonRepo)
+	sensitiveWordService := services.NewSensitiveWordService(sensitiveWordRepo, contentFilter)
+	if err := sensitiveWordService.Reload(); err != nil {
+		log.Fatalf("load sensitive words: %v", err)
+	}
+
+	notificationService := services.NewNotificationService(notificationRepo, reviewRepo, commentRepo)
+	reviewMachine.Subscribe(notificationService.OnReviewEvent)
+	eventHub := realtime.NewHub(cfg.Realtime.Backlog)
+	reviewMachine.Subscribe(eventHub.OnReviewEvent)
+	notificationService.Subscribe(eventHub.OnNotification)
+	reportService := services.NewReportService(reviewReportRepo, reviewService, cfg.Review.ReportThreshold, notificationService)
+	reviewMachine.Subscribe(reportService.OnReviewEvent)
+	commentService := services.NewCommentService(commentRepo, contentFilter, commentPipeline, notificationService)
+	voteService := services.NewVoteService(reviewVoteRepo, reviewRepo)
+	favoriteService := services.NewFavoriteService(favoriteRepo)
+	followService := services.NewFollowService(followRepo, userRepo, reviewRepo, notificationService)
+
+	if cfg.Review.PurgeInterval > 0 {
+		go reviewService.RunTrashPurger(context.Background(), cfg.Review.PurgeInterval, cfg.Review.TrashRetention)
+	}
 
 	authHandler := handlers.NewAuthHandler(authService)
 	userHandler := handlers.NewUserHandler(userRepo)
-	reviewHandler := handlers.NewReviewHandler(reviewService)
+	reviewHandler := handlers.NewReviewHandler(reviewService, favoriteService)
+	placeHandler := handlers.NewPlaceHandler(placeService, reviewService, favoriteService)
+	reportHandler := handlers.NewReportHandler(reportService, reviewService)
+	commentHandler := handlers.NewCommentHandler(commentService, reviewService)
+	voteHandler := handlers.NewVoteHandler(voteService, reviewService)
+	favoriteHandler := handlers.NewFavoriteHandler(favoriteService, reviewService, placeService)
+	followHandler := handlers.NewFollowHandler(followService, favoriteService)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	streamHandler := handlers.NewStreamHandler(eventHub, userRepo, cfg.Realtime.Heartbeat)
 	adminReviewHandler := adminHandlers.NewReviewAdminHandler(reviewService)
+	adminPlaceHandler := adminHandlers.NewPlaceAdminHandler(placeService)
+	adminRejectionReasonHandler := adminHandlers.NewRejectionReasonAdminHandler(rejectionReasonService)
+	adminSensitiveWordHandler := adminHandlers.NewSensitiveWordAdminHandler(sensitiveWordService)
+	adminReportHandler := adminHandlers.NewReportAdminHandler(reportService)
+	adminCommentHandler := adminHandlers.NewCommentAdminHandler(commentService)
 
 	authMiddleware := middleware.NewAuthMiddleware(jwtManager)
 
@@ -76,8 +152,8 @@ func main() {
 	engine.Use(cors.New(cors.Config{
 		AllowOrigins:  []string{"*"},
 		AllowMethods:  []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:  []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders: []string{"Content-Length"},
+		AllowHeaders:  []string{"Origin", "Content-Type", "Authorization", "If-Match", "If-None-Match", "Last-Event-ID"},
+		ExposeHeaders: []string{"Content-Length", "ETag"},
 	}))
 
 	staticUploads := cfg.Storage.UploadDir
@@ -86,13 +162,26 @@ func main() {
 	}
 
 	router.Register(router.Params{
-		Engine:          engine,
-		AuthMiddleware:  authMiddleware,
-		AuthHandler:     authHandler,
-		UserHandler:     userHandler,
-		ReviewHandler:   reviewHandler,
-		AdminHandler:    adminReviewHandler,
-		StaticUploadDir: staticUploads,
+		Engine:                      engine,
+		AuthMiddleware:              authMiddleware,
+		AuthHandler:                 authHandler,
+		UserHandler:                 userHandler,
+		ReviewHandler:               reviewHandler,
+		PlaceHandler:                placeHandler,
+		ReportHandler:               reportHandler,
+		CommentHandler:              commentHandler,
+		VoteHandler:                 voteHandler,
+		FavoriteHandler:             favoriteHandler,
+		FollowHandler:               followHandler,
+		NotificationHandler:         notificationHandler,
+		StreamHandler:               streamHandler,
+		AdminHandler:                adminReviewHandler,
+		AdminPlaceHandler:           adminPlaceHandler,
+		AdminRejectionReasonHandler: adminRejectionReasonHandler,
+		AdminSensitiveWordHandler:   adminSensitiveWordHandler,
+		AdminReportHandler:          adminReportHandler,
+		AdminCommentHandler:         adminCommentHandler,
+		StaticUploadDir:             staticUploads,
 	})
 
 	if err := engine.Run(":" + cfg.Server.Port); err != nil {
@@ -11,4 +11,65 @@ var (
 	ErrReviewAlreadyProcessed = errors.New("review already processed")
 	// ErrInvalidRefreshToken indicates the provided refresh token is invalid or expired.
 	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrReviewNotWithdrawable indicates the review has nothing awaiting moderation.
+	ErrReviewNotWithdrawable = errors.New("only reviews awaiting moderation can be withdrawn")
+	// ErrReviewNotSubmittable indicates the review is neither a draft nor withdrawn.
+	ErrReviewNotSubmittable = errors.New("only drafts and withdrawn reviews can be submitted")
+	// ErrInvalidTransition indicates the review's status does not allow the requested action.
+	ErrInvalidTransition = errors.New("action not allowed in the review's current status")
+	// ErrTransitionForbidden indicates the caller may not perform the requested action.
+	ErrTransitionForbidden = errors.New("not permitted to perform this action")
+	// ErrReviewNotAppealable indicates only rejected reviews can be appealed.
+	ErrReviewNotAppealable = errors.New("only rejected reviews can be appealed")
+	// ErrAppealExists indicates the review has already been appealed.
+	ErrAppealExists = errors.New("review has already been appealed")
+	// ErrReviewClaimed indicates another administrator holds the review's moderation claim.
+	ErrReviewClaimed = errors.New("review is claimed by another administrator")
+	// ErrReviewNotClaimable indicates the review is not awaiting a moderation decision.
+	ErrReviewNotClaimable = errors.New("only reviews awaiting moderation can be claimed")
+	// ErrVersionConflict indicates the review changed since it was read.
+	ErrVersionConflict = errors.New("review was modified concurrently, reload and retry")
+	// ErrRejectionCodeTaken indicates another rejection template uses the code.
+	ErrRejectionCodeTaken = errors.New("rejection reason code already exists")
+	// ErrUnknownRejectionReason indicates no active rejection template has the code.
+	ErrUnknownRejectionReason = errors.New("unknown or inactive rejection reason code")
+	// ErrSensitiveWordExists indicates the word is already on the list.
+	ErrSensitiveWordExists = errors.New("sensitive word already exists")
+	// ErrInvalidReview indicates the review content failed validation.
+	ErrInvalidReview = errors.New("invalid review")
+	// ErrInvalidComment indicates the comment failed validation.
+	ErrInvalidComment = errors.New("invalid comment")
+	// ErrContentBlocked indicates the content contains words that may not be published.
+	ErrContentBlocked = errors.New("content contains blocked words")
+	// ErrDuplicateReview indicates the author already has a review with the same text.
+	ErrDuplicateReview = errors.New("you have already posted a review with the same text")
+	// ErrReviewNotReportable indicates only published reviews can be reported.
+	ErrReviewNotReportable = errors.New("only published reviews can be reported")
+	// ErrOwnReviewReport indicates authors cannot report their own reviews.
+	ErrOwnReviewReport = errors.New("you cannot report your own review")
+	// ErrAlreadyReported indicates the user has already reported the review.
+	ErrAlreadyReported = errors.New("you have already reported this review")
+	// ErrReportClosed indicates the report has already been resolved or dismissed.
+	ErrReportClosed = errors.New("report has already been handled")
+	// ErrReviewNotCommentable indicates only published reviews accept comments.
+	ErrReviewNotCommentable = errors.New("only published reviews can be commented on")
+	// ErrCommentNotFound indicates the comment does not exist on the review.
+	ErrCommentNotFound = errors.New("comment not found")
+	// ErrCommentAlreadyProcessed indicates the comment is not awaiting moderation.
+	ErrCommentAlreadyProcessed = errors.New("comment already processed")
+	// ErrReviewNotVotable indicates only published reviews can be voted on.
+	ErrReviewNotVotable = errors.New("only published reviews can be voted on")
+	// ErrOwnReviewVote indicates authors cannot vote on their own reviews.
+	ErrOwnReviewVote = errors.New("you cannot vote on your own review")
+	// ErrReviewNotFavoritable indicates only published reviews can be saved.
+	ErrReviewNotFavoritable = errors.New("only published reviews can be added to favorites")
+	// ErrSelfFollow indicates users cannot follow themselves.
+	ErrSelfFollow = errors.New("you cannot follow yourself")
+	// ErrNotificationNotFound indicates the notification does not exist or
+	// belongs to someone else.
+	ErrNotificationNotFound = errors.New("notification not found")
+	// ErrInvalidCursor indicates a feed cursor that was not issued by the feed.
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrPlaceNotFound indicates a referenced place does not exist.
+	ErrPlaceNotFound = errors.New("place not found")
 )
@@ -40,6 +40,43 @@ type Config struct {
 		Email    string
 		Password string
 	}
+	Rating struct {
+		PriorMean   float64
+		PriorWeight float64
+	}
+	Review struct {
+		TrashRetention time.Duration
+		PurgeInterval  time.Duration
+		ClaimTTL       time.Duration
+		// DuplicateDistance is the largest fingerprint distance, in bits, at
+		// which two reviews count as near duplicates.
+		DuplicateDistance int
+		// BlockDuplicates refuses exact re-submissions of an author's review.
+		BlockDuplicates bool
+		// ReportThreshold is the number of open reader reports that sends a
+		// published review back to moderation; 0 disables it.
+		ReportThreshold int
+	}
+	Moderation struct {
+		// Enabled runs the pre-moderation pipeline on content entering moderation.
+		Enabled bool
+		// AutoApproveBelow and AutoRejectAt are risk score thresholds; 0
+		// disables the corresponding automatic decision. Auto-approval is
+		// opt-in: by default every review still waits for an admin.
+		AutoApproveBelow float64
+		AutoRejectAt     float64
+		// CommentAutoApproveBelow and CommentAutoRejectAt are the thresholds
+		// for comments, which are assessed on their own text only.
+		CommentAutoApproveBelow float64
+		CommentAutoRejectAt     float64
+	}
+	Realtime struct {
+		// Backlog is how many recent events are kept for clients resuming
+		// with Last-Event-ID.
+		Backlog int
+		// Heartbeat is the interval of keep-alive comments on idle streams.
+		Heartbeat time.Duration
+	}
 }
 
 // Load reads configuration from environment variables with sane defaults.
@@ -69,6 +106,25 @@ func Load() (*Config, error) {
 	v.SetDefault("STORAGE_S3_USE_SSL", true)
 	v.SetDefault("STORAGE_S3_BASE_URL", "")
 
+	v.SetDefault("RATING_PRIOR_MEAN", 3.5)
+	v.SetDefault("RATING_PRIOR_WEIGHT", 5)
+
+	v.SetDefault("REVIEW_TRASH_RETENTION", "720h")
+	v.SetDefault("REVIEW_PURGE_INTERVAL", "1h")
+	v.SetDefault("REVIEW_CLAIM_TTL", "10m")
+	v.SetDefault("REVIEW_DUPLICATE_DISTANCE", 12)
+	v.SetDefault("REVIEW_BLOCK_DUPLICATES", false)
+	v.SetDefault("REVIEW_REPORT_THRESHOLD", 3)
+
+	v.SetDefault("MODERATION_ENABLED", true)
+	v.SetDefault("MODERATION_AUTO_APPROVE_BELOW", 0)
+	v.SetDefault("MODERATION_AUTO_REJECT_AT", 0.9)
+	v.SetDefault("MODERATION_COMMENT_AUTO_APPROVE_BELOW", 0)
+	v.SetDefault("MODERATION_COMMENT_AUTO_REJECT_AT", 0.9)
+
+	v.SetDefault("REALTIME_BACKLOG", 500)
+	v.SetDefault("REALTIME_HEARTBEAT", "25s")
+
 	accessTTL, err := time.ParseDuration(v.GetString("AUTH_ACCESS_TOKEN_TTL"))
 	if err != nil {
 		return nil, fmt.Errorf("invalid ACCESS_TOKEN ttl: %w", err)
@@ -79,6 +135,26 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("invalid REFRESH_TOKEN ttl: %w", err)
 	}
 
+	trashRetention, err := time.ParseDuration(v.GetString("REVIEW_TRASH_RETENTION"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid REVIEW_TRASH_RETENTION: %w", err)
+	}
+
+	purgeInterval, err := time.ParseDuration(v.GetString("REVIEW_PURGE_INTERVAL"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid REVIEW_PURGE_INTERVAL: %w", err)
+	}
+
+	claimTTL, err := time.ParseDuration(v.GetString("REVIEW_CLAIM_TTL"))
+	if err != nil || claimTTL <= 0 {
+		return nil, fmt.Errorf("invalid REVIEW_CLAIM_TTL: must be a positive duration")
+	}
+
+	heartbeat, err := time.ParseDuration(v.GetString("REALTIME_HEARTBEAT"))
+	if err != nil || heartbeat <= 0 {
+		return nil, fmt.Errorf("invalid REALTIME_HEARTBEAT: must be a positive duration")
+	}
+
 	cfg := &Config{}
 	cfg.Server.Port = v.GetString("SERVER_PORT")
 	cfg.Server.Mode = v.GetString("SERVER_MODE")
@@ -104,6 +180,51 @@ func Load() (*Config, error) {
 	cfg.Admin.Email = v.GetString("ADMIN_EMAIL")
 	cfg.Admin.Password = v.GetString("ADMIN_PASSWORD")
 
+	cfg.Rating.PriorMean = v.GetFloat64("RATING_PRIOR_MEAN")
+	cfg.Rating.PriorWeight = v.GetFloat64("RATING_PRIOR_WEIGHT")
+
+	cfg.Review.TrashRetention = trashRetention
+	cfg.Review.PurgeInterval = purgeInterval
+	cfg.Review.ClaimTTL = claimTTL
+	cfg.Review.DuplicateDistance = v.GetInt("REVIEW_DUPLICATE_DISTANCE")
+	cfg.Review.BlockDuplicates = v.GetBool("REVIEW_BLOCK_DUPLICATES")
+	cfg.Review.ReportThreshold = v.GetInt("REVIEW_REPORT_THRESHOLD")
+
+	cfg.Moderation.Enabled = v.GetBool("MODERATION_ENABLED")
+	cfg.Moderation.AutoApproveBelow = v.GetFloat64("MODERATION_AUTO_APPROVE_BELOW")
+	cfg.Moderation.AutoRejectAt = v.GetFloat64("MODERATION_AUTO_REJECT_AT")
+	cfg.Moderation.CommentAutoApproveBelow = v.GetFloat64("MODERATION_COMMENT_AUTO_APPROVE_BELOW")
+	cfg.Moderation.CommentAutoRejectAt = v.GetFloat64("MODERATION_COMMENT_AUTO_REJECT_AT")
+
+	cfg.Realtime.Backlog = v.GetInt("REALTIME_BACKLOG")
+	cfg.Realtime.Heartbeat = heartbeat
+
+	if cfg.Rating.PriorWeight < 0 {
+		return nil, fmt.Errorf("invalid RATING_PRIOR_WEIGHT: must not be negative")
+	}
+
+	if cfg.Review.DuplicateDistance < 0 || cfg.Review.DuplicateDistance > 64 {
+		return nil, fmt.Errorf("invalid REVIEW_DUPLICATE_DISTANCE: must be between 0 and 64")
+	}
+
+	if cfg.Review.ReportThreshold < 0 {
+		return nil, fmt.Errorf("invalid REVIEW_REPORT_THRESHOLD: must not be negative")
+	}
+
+	if cfg.Realtime.Backlog < 0 {
+		return nil, fmt.Errorf("invalid REALTIME_BACKLOG: must not be negative")
+	}
+
+	if cfg.Moderation.AutoApproveBelow < 0 || cfg.Moderation.AutoRejectAt < 0 ||
+		(cfg.Moderation.AutoRejectAt > 0 && cfg.Moderation.AutoApproveBelow > cfg.Moderation.AutoRejectAt) {
+		return nil, fmt.Errorf("invalid moderation thresholds: APP_MODERATION_AUTO_APPROVE_BELOW must not exceed APP_MODERATION_AUTO_REJECT_AT")
+	}
+
+	if cfg.Moderation.CommentAutoApproveBelow < 0 || cfg.Moderation.CommentAutoRejectAt < 0 ||
+		(cfg.Moderation.CommentAutoRejectAt > 0 && cfg.Moderation.CommentAutoApproveBelow > cfg.Moderation.CommentAutoRejectAt) {
+		return nil, fmt.Errorf("invalid moderation thresholds: APP_MODERATION_COMMENT_AUTO_APPROVE_BELOW must not exceed APP_MODERATION_COMMENT_AUTO_REJECT_AT")
+	}
+
 	if cfg.Auth.JWTSecret == "" {
 		return nil, fmt.Errorf("missing auth jwt secret: set APP_AUTH_JWT_SECRET")
 	}
@@ -34,10 +34,14 @@ func Init(cfg *config.Config) (*gorm.DB, error) {
 		return nil, err
 	}
 
-	if err = db.AutoMigrate(&models.User{}, &models.Review{}, &models.ReviewImage{}, &models.RefreshToken{}); err != nil {
+	if err = db.AutoMigrate(&models.User{}, &models.Place{}, &models.Review{}, &models.ReviewImage{}, &models.ReviewEdit{}, &models.ReviewRevision{}, &models.ReviewAppeal{}, &models.ReviewAssessment{}, &models.ReviewFingerprint{}, &models.ReviewReport{}, &models.Comment{}, &models.ReviewVote{}, &models.Favorite{}, &models.Follow{}, &models.Notification{}, &models.ModerationAction{}, &models.RejectionReason{}, &models.SensitiveWord{}, &models.RefreshToken{}); err != nil {
 		return nil, fmt.Errorf("auto migrate: %w", err)
 	}
 
+	if err = backfillPlaceKeys(db); err != nil {
+		return nil, fmt.Errorf("backfill place keys: %w", err)
+	}
+
 	if err = seedAdmin(db, cfg); err != nil {
 		return nil, err
 	}
@@ -52,6 +56,25 @@ func Init(cfg *config.Config) (*gorm.DB, error) {
 	return db, nil
 }
 
+// backfillPlaceKeys fills in the match keys of places stored before the
+// columns existed.
+func backfillPlaceKeys(db *gorm.DB) error {
+	var places []models.Place
+	if err := db.Where("name_key = '' OR name_key IS NULL").Find(&places).Error; err != nil {
+		return err
+	}
+	for i := range places {
+		places[i].SyncMatchKeys()
+		if err := db.Model(&places[i]).UpdateColumns(map[string]interface{}{
+			"name_key":    places[i].NameKey,
+			"address_key": places[i].AddressKey,
+		}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func ensureDir(path string) error {
 	if path == "" || path == "." {
 		return nil
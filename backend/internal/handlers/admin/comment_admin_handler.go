@@ -0,0 +1,150 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
+	"github.com/hdu-dp/backend/internal/models"
+	"github.com/hdu-dp/backend/internal/services"
+)
+
+// CommentAdminHandler exposes comment moderation to administrators.
+type CommentAdminHandler struct {
+	comments *services.CommentService
+}
+
+// NewCommentAdminHandler constructs a new handler.
+func NewCommentAdminHandler(comments *services.CommentService) *CommentAdminHandler {
+	return &CommentAdminHandler{comments: comments}
+}
+
+// @Summary      待审核评论列表
+// @Description  获取未被自动预审通过或驳回、等待管理员审核的评论，按发表时间先后排序。
+// @Tags         管理
+// @Produce      json
+// @Param        page      query int  false "页码" default(1)
+// @Param        page_size query int  false "每页数量" default(10)
+// @Param        flagged   query bool false "仅返回被敏感词过滤标记的评论"
+// @Success      200 {object} services.CommentListResult
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /admin/comments/pending [get]
+func (h *CommentAdminHandler) Pending(c *gin.Context) {
+	result, err := h.comments.ListPending(services.CommentFilters{
+		Page:        mustAtoi(c.DefaultQuery("page", "1")),
+		PageSize:    mustAtoi(c.DefaultQuery("page_size", "10")),
+		FlaggedOnly: c.Query("flagged") == "true",
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary      审核通过评论
+// @Description  公开一条待审核的评论。
+// @Tags         管理
+// @Produce      json
+// @Param        id path string true "评论 ID"
+// @Success      200 {object} models.Comment "审核成功"
+// @Failure      400 {object} object{error=string} "无效的评论 ID"
+// @Failure      404 {object} object{error=string} "评论不存在"
+// @Failure      409 {object} object{error=string} "评论已处理"
+// @Security     ApiKeyAuth
+// @Router       /admin/comments/{id}/approve [put]
+func (h *CommentAdminHandler) Approve(c *gin.Context) {
+	comment, ok := h.load(c)
+	if !ok {
+		return
+	}
+
+	if err := h.comments.Approve(comment); err != nil {
+		if errors.Is(err, common.ErrCommentAlreadyProcessed) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, comment)
+}
+
+// @Summary      驳回评论
+// @Description  驳回一条待审核的评论，驳回原因对作者可见。
+// @Tags         管理
+// @Accept       json
+// @Produce      json
+// @Param        id   path string true "评论 ID"
+// @Param        body body object{reason=string} true "驳回原因"
+// @Success      200 {object} models.Comment "驳回成功"
+// @Failure      400 {object} object{error=string} "无效的评论 ID 或缺少驳回原因"
+// @Failure      404 {object} object{error=string} "评论不存在"
+// @Failure      409 {object} object{error=string} "评论已处理"
+// @Security     ApiKeyAuth
+// @Router       /admin/comments/{id}/reject [put]
+func (h *CommentAdminHandler) Reject(c *gin.Context) {
+	comment, ok := h.load(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	if err := h.comments.Reject(comment, req.Reason); err != nil {
+		if errors.Is(err, common.ErrCommentAlreadyProcessed) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, comment)
+}
+
+// @Summary      删除评论
+// @Description  删除任意评论，顶层评论的回复一并删除。
+// @Tags         管理
+// @Param        id path string true "评论 ID"
+// @Success      204 "删除成功"
+// @Failure      400 {object} object{error=string} "无效的评论 ID"
+// @Failure      404 {object} object{error=string} "评论不存在"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /admin/comments/{id} [delete]
+func (h *CommentAdminHandler) Delete(c *gin.Context) {
+	comment, ok := h.load(c)
+	if !ok {
+		return
+	}
+
+	if err := h.comments.Delete(comment); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *CommentAdminHandler) load(c *gin.Context) (*models.Comment, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment id"})
+		return nil, false
+	}
+
+	comment, err := h.comments.Get(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "comment not found"})
+		return nil, false
+	}
+	return comment, true
+}
@@ -0,0 +1,181 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/services"
+)
+
+// PlaceAdminHandler exposes place curation endpoints for administrators.
+type PlaceAdminHandler struct {
+	places *services.PlaceService
+}
+
+// NewPlaceAdminHandler constructs a new handler.
+func NewPlaceAdminHandler(places *services.PlaceService) *PlaceAdminHandler {
+	return &PlaceAdminHandler{places: places}
+}
+
+// @Summary      更新地点
+// @Description  修改指定地点的名称、规范地址、校区与分类。
+// @Tags         管理
+// @Accept       json
+// @Produce      json
+// @Param        id   path string true "地点 ID"
+// @Param        body body object{name=string,address=string,campus_area=string,category=string} true "地点信息"
+// @Success      200  {object} models.Place "更新成功"
+// @Failure      400  {object} object{error=string} "无效的地点 ID 或请求参数错误"
+// @Failure      404  {object} object{error=string} "地点不存在"
+// @Security     ApiKeyAuth
+// @Router       /admin/places/{id} [put]
+func (h *PlaceAdminHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid place id"})
+		return
+	}
+
+	place, err := h.places.Get(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "place not found"})
+		return
+	}
+
+	var req struct {
+		Name       string `json:"name"`
+		Address    string `json:"address"`
+		CampusArea string `json:"campus_area"`
+		Category   string `json:"category"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	if err := h.places.Update(place, services.PlaceInput{
+		Name:       req.Name,
+		Address:    req.Address,
+		CampusArea: req.CampusArea,
+		Category:   req.Category,
+	}); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, place)
+}
+
+// @Summary      确认地点
+// @Description  将用户创建的待确认地点标记为生效。
+// @Tags         管理
+// @Produce      json
+// @Param        id path string true "地点 ID"
+// @Success      200 {object} models.Place "确认成功"
+// @Failure      400 {object} object{error=string} "无效的地点 ID"
+// @Failure      404 {object} object{error=string} "地点不存在"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /admin/places/{id}/confirm [put]
+func (h *PlaceAdminHandler) Confirm(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid place id"})
+		return
+	}
+
+	place, err := h.places.Get(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "place not found"})
+		return
+	}
+
+	if err := h.places.Confirm(place); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, place)
+}
+
+// @Summary      删除地点
+// @Description  删除指定地点，关联点评保留原地址文本并解除关联。
+// @Tags         管理
+// @Produce      json
+// @Param        id path string true "地点 ID"
+// @Success      204 "删除成功"
+// @Failure      400 {object} object{error=string} "无效的地点 ID"
+// @Failure      404 {object} object{error=string} "地点不存在"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /admin/places/{id} [delete]
+func (h *PlaceAdminHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid place id"})
+		return
+	}
+
+	place, err := h.places.Get(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "place not found"})
+		return
+	}
+
+	if err := h.places.Delete(place); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      重算地点评分
+// @Description  根据已审核点评重新计算指定地点的评分聚合（点评数、均分、分布与贝叶斯评分）。
+// @Tags         管理
+// @Produce      json
+// @Param        id path string true "地点 ID"
+// @Success      200 {object} models.Place "重算成功"
+// @Failure      400 {object} object{error=string} "无效的地点 ID"
+// @Failure      404 {object} object{error=string} "地点不存在"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /admin/places/{id}/recompute [post]
+func (h *PlaceAdminHandler) RecomputeStats(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid place id"})
+		return
+	}
+
+	place, err := h.places.Get(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "place not found"})
+		return
+	}
+
+	if err := h.places.RecomputeStats(place); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, place)
+}
+
+// @Summary      迁移历史点评到地点
+// @Description  按地址模糊匹配，为尚未关联地点的点评关联已有地点；无法匹配时创建待确认地点。可重复执行。
+// @Tags         管理
+// @Produce      json
+// @Success      200 {object} services.PlaceMigrationResult
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /admin/places/migrate [post]
+func (h *PlaceAdminHandler) MigrateReviews(c *gin.Context) {
+	result, err := h.places.MigrateReviews()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,166 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
+	"github.com/hdu-dp/backend/internal/services"
+)
+
+// RejectionReasonAdminHandler exposes rejection template management for
+// administrators.
+type RejectionReasonAdminHandler struct {
+	reasons *services.RejectionReasonService
+}
+
+// NewRejectionReasonAdminHandler constructs a new handler.
+func NewRejectionReasonAdminHandler(reasons *services.RejectionReasonService) *RejectionReasonAdminHandler {
+	return &RejectionReasonAdminHandler{reasons: reasons}
+}
+
+type rejectionReasonRequest struct {
+	Code         string            `json:"code"`
+	Title        string            `json:"title"`
+	Message      string            `json:"message"`
+	Translations map[string]string `json:"translations"`
+	Active       *bool             `json:"active"`
+}
+
+func (r rejectionReasonRequest) input() services.RejectionReasonInput {
+	return services.RejectionReasonInput{
+		Code:         r.Code,
+		Title:        r.Title,
+		Message:      r.Message,
+		Translations: r.Translations,
+		Active:       r.Active,
+	}
+}
+
+// @Summary      驳回模板列表
+// @Description  获取全部驳回模板，按代码排序。
+// @Tags         管理
+// @Produce      json
+// @Param        active query bool false "仅返回启用的模板"
+// @Success      200 {array}  models.RejectionReason
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /admin/rejection-reasons [get]
+func (h *RejectionReasonAdminHandler) List(c *gin.Context) {
+	reasons, err := h.reasons.List(c.Query("active") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, reasons)
+}
+
+// @Summary      新建驳回模板
+// @Description  新建驳回模板。code 为小写字母、数字、“-”或“_”组成的唯一代码；message 为默认文案，translations 按语言（如 en、zh-tw）提供译文；active 缺省为 true。
+// @Tags         管理
+// @Accept       json
+// @Produce      json
+// @Param        body body object{code=string,title=string,message=string,translations=object,active=bool} true "模板信息"
+// @Success      201  {object} models.RejectionReason "创建成功"
+// @Failure      400  {object} object{error=string} "请求参数错误"
+// @Failure      409  {object} object{error=string} "代码已存在"
+// @Security     ApiKeyAuth
+// @Router       /admin/rejection-reasons [post]
+func (h *RejectionReasonAdminHandler) Create(c *gin.Context) {
+	var req rejectionReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	reason, err := h.reasons.Create(req.input())
+	if err != nil {
+		c.JSON(rejectionReasonErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, reason)
+}
+
+// @Summary      更新驳回模板
+// @Description  修改指定驳回模板；未提供 active 时保持原启用状态。停用的模板不能再用于驳回，已使用该模板的点评仍按模板展示文案。
+// @Tags         管理
+// @Accept       json
+// @Produce      json
+// @Param        id   path string true "模板 ID"
+// @Param        body body object{code=string,title=string,message=string,translations=object,active=bool} true "模板信息"
+// @Success      200  {object} models.RejectionReason "更新成功"
+// @Failure      400  {object} object{error=string} "无效的模板 ID 或请求参数错误"
+// @Failure      404  {object} object{error=string} "模板不存在"
+// @Failure      409  {object} object{error=string} "代码已存在"
+// @Security     ApiKeyAuth
+// @Router       /admin/rejection-reasons/{id} [put]
+func (h *RejectionReasonAdminHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid rejection reason id"})
+		return
+	}
+
+	reason, err := h.reasons.Get(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "rejection reason not found"})
+		return
+	}
+
+	var req rejectionReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	if err := h.reasons.Update(reason, req.input()); err != nil {
+		c.JSON(rejectionReasonErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reason)
+}
+
+// @Summary      删除驳回模板
+// @Description  删除指定驳回模板。已使用该模板的点评保留驳回原因，但不再展示模板文案；如需保留请改为停用。
+// @Tags         管理
+// @Produce      json
+// @Param        id path string true "模板 ID"
+// @Success      204 "删除成功"
+// @Failure      400 {object} object{error=string} "无效的模板 ID"
+// @Failure      404 {object} object{error=string} "模板不存在"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /admin/rejection-reasons/{id} [delete]
+func (h *RejectionReasonAdminHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid rejection reason id"})
+		return
+	}
+
+	reason, err := h.reasons.Get(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "rejection reason not found"})
+		return
+	}
+
+	if err := h.reasons.Delete(reason); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// rejectionReasonErrorStatus maps duplicate codes to 409 and validation
+// failures to 400.
+func rejectionReasonErrorStatus(err error) int {
+	if errors.Is(err, common.ErrRejectionCodeTaken) {
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,133 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
+	"github.com/hdu-dp/backend/internal/models"
+	"github.com/hdu-dp/backend/internal/services"
+)
+
+// ReportAdminHandler exposes the reader report queue to administrators.
+type ReportAdminHandler struct {
+	reports *services.ReportService
+}
+
+// NewReportAdminHandler constructs a new handler.
+func NewReportAdminHandler(reports *services.ReportService) *ReportAdminHandler {
+	return &ReportAdminHandler{reports: reports}
+}
+
+// @Summary      举报列表
+// @Description  获取读者对已发布点评的举报，按举报时间先后排序，附带被举报的点评与举报人。回收站中点评的举报不列出。
+// @Tags         管理
+// @Produce      json
+// @Param        page      query int    false "页码" default(1)
+// @Param        page_size query int    false "每页数量" default(10)
+// @Param        status    query string false "处理状态 (open, resolved, dismissed, all)" enums(open, resolved, dismissed, all) default(open)
+// @Param        reason    query string false "举报原因" enums(spam, offensive, inaccurate, irrelevant, privacy, other)
+// @Param        review_id query string false "点评 ID"
+// @Success      200 {object} services.ReportListResult
+// @Failure      400 {object} object{error=string} "无效的点评 ID"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /admin/reports [get]
+func (h *ReportAdminHandler) List(c *gin.Context) {
+	filters := services.ReportFilters{
+		Page:     mustAtoi(c.DefaultQuery("page", "1")),
+		PageSize: mustAtoi(c.DefaultQuery("page_size", "10")),
+		Status:   models.ReportStatus(c.DefaultQuery("status", string(models.ReportStatusOpen))),
+		Reason:   models.ReportReason(c.Query("reason")),
+	}
+	if filters.Status == "all" {
+		filters.Status = ""
+	}
+	if raw := c.Query("review_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+			return
+		}
+		filters.ReviewID = &id
+	}
+
+	result, err := h.reports.List(filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary      采纳举报
+// @Description  将未处理的举报标记为成立（resolved）。仅记录处理结果，驳回或删除点评请使用点评审核接口；点评被驳回或删除时其未处理举报会自动标记为成立。
+// @Tags         管理
+// @Accept       json
+// @Produce      json
+// @Param        id   path string true "举报 ID"
+// @Param        body body object{note=string} false "处理备注"
+// @Success      200 {object} models.ReviewReport "处理成功"
+// @Failure      400 {object} object{error=string} "无效的举报 ID 或请求参数错误"
+// @Failure      404 {object} object{error=string} "举报不存在"
+// @Failure      409 {object} object{error=string} "举报已处理"
+// @Security     ApiKeyAuth
+// @Router       /admin/reports/{id}/resolve [put]
+func (h *ReportAdminHandler) Resolve(c *gin.Context) {
+	h.close(c, h.reports.Resolve)
+}
+
+// @Summary      驳回举报
+// @Description  将未处理的举报标记为不成立（dismissed）。重新进入审核的点评被审核通过时，其未处理举报会自动标记为不成立。
+// @Tags         管理
+// @Accept       json
+// @Produce      json
+// @Param        id   path string true "举报 ID"
+// @Param        body body object{note=string} false "处理备注"
+// @Success      200 {object} models.ReviewReport "处理成功"
+// @Failure      400 {object} object{error=string} "无效的举报 ID 或请求参数错误"
+// @Failure      404 {object} object{error=string} "举报不存在"
+// @Failure      409 {object} object{error=string} "举报已处理"
+// @Security     ApiKeyAuth
+// @Router       /admin/reports/{id}/dismiss [put]
+func (h *ReportAdminHandler) Dismiss(c *gin.Context) {
+	h.close(c, h.reports.Dismiss)
+}
+
+func (h *ReportAdminHandler) close(c *gin.Context, op func(*models.ReviewReport, uuid.UUID, string) error) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid report id"})
+		return
+	}
+
+	report, err := h.reports.Get(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "report not found"})
+		return
+	}
+
+	var req struct {
+		Note string `json:"note"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+			return
+		}
+	}
+
+	adminID := c.MustGet("user_id").(uuid.UUID)
+	if err := op(report, adminID, req.Note); err != nil {
+		if errors.Is(err, common.ErrReportClosed) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -1,13 +1,18 @@
 package admin
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
+	"github.com/hdu-dp/backend/internal/handlers"
 	"github.com/hdu-dp/backend/internal/services"
+	"github.com/hdu-dp/backend/internal/workflow"
 )
 
 // ReviewAdminHandler contains endpoints reserved for administrators.
@@ -21,14 +26,17 @@ func NewReviewAdminHandler(reviews *services.ReviewService) *ReviewAdminHandler
 }
 
 // @Summary      待审核点评列表
-// @Description  获取等待管理员审核的点评列表，支持分页、搜索和排序。
+// @Description  获取等待管理员审核的点评列表（包含有待审核修改的已通过点评，修改内容见 pending_edit），支持分页、搜索和排序。
 // @Tags         管理
 // @Produce      json
 // @Param        page      query int    false "页码" default(1)
 // @Param        page_size query int    false "每页数量" default(10)
 // @Param        query     query string false "搜索关键词"
-// @Param        sort      query string false "排序字段 (created_at, rating)" enums(created_at, rating) default(created_at)
+// @Param        sort      query string false "排序字段 (created_at, rating, score, helpful, taste, value, service, hygiene)" enums(created_at, rating, score, helpful, taste, value, service, hygiene) default(created_at)
 // @Param        order     query string false "排序顺序 (asc, desc)" enums(asc, desc) default(desc)
+// @Param        hide_claimed query bool false "隐藏其他管理员认领中的点评"
+// @Param        flagged      query bool false "仅返回被敏感词过滤标记的点评"
+// @Param        duplicates   query bool false "仅返回与其他点评内容重复或相近的点评"
 // @Success      200 {object} services.ReviewListResult
 // @Failure      500 {object} object{error=string} "服务器内部错误"
 // @Security     ApiKeyAuth
@@ -41,6 +49,12 @@ func (h *ReviewAdminHandler) Pending(c *gin.Context) {
 		SortBy:   c.DefaultQuery("sort", "created_at"),
 		SortDir:  c.DefaultQuery("order", "desc"),
 	}
+	if c.Query("hide_claimed") == "true" {
+		adminID := c.MustGet("user_id").(uuid.UUID)
+		filters.HideClaimedFor = &adminID
+	}
+	filters.FlaggedOnly = c.Query("flagged") == "true"
+	filters.DuplicatesOnly = c.Query("duplicates") == "true"
 
 	result, err := h.reviews.ListPending(filters)
 	if err != nil {
@@ -50,14 +64,159 @@ func (h *ReviewAdminHandler) Pending(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// @Summary      申诉中的点评列表
+// @Description  获取作者已提出申诉、等待处理的点评列表（申诉内容见 appeal），支持分页、搜索和排序。
+// @Tags         管理
+// @Produce      json
+// @Param        page      query int    false "页码" default(1)
+// @Param        page_size query int    false "每页数量" default(10)
+// @Param        query     query string false "搜索关键词"
+// @Param        sort      query string false "排序字段 (created_at, rating, score, helpful, taste, value, service, hygiene)" enums(created_at, rating, score, helpful, taste, value, service, hygiene) default(created_at)
+// @Param        order     query string false "排序顺序 (asc, desc)" enums(asc, desc) default(desc)
+// @Param        hide_claimed query bool false "隐藏其他管理员认领中的点评"
+// @Success      200 {object} services.ReviewListResult
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /admin/reviews/appealed [get]
+func (h *ReviewAdminHandler) Appealed(c *gin.Context) {
+	filters := services.ListFilters{
+		Page:     mustAtoi(c.DefaultQuery("page", "1")),
+		PageSize: mustAtoi(c.DefaultQuery("page_size", "10")),
+		Query:    strings.TrimSpace(c.Query("query")),
+		SortBy:   c.DefaultQuery("sort", "created_at"),
+		SortDir:  c.DefaultQuery("order", "desc"),
+	}
+	if c.Query("hide_claimed") == "true" {
+		adminID := c.MustGet("user_id").(uuid.UUID)
+		filters.HideClaimedFor = &adminID
+	}
+
+	result, err := h.reviews.ListAppealed(filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary      接受申诉
+// @Description  接受作者的申诉，撤销驳回并将点评标记为“已批准”，可附带回复。
+// @Tags         管理
+// @Accept       json
+// @Produce      json
+// @Param        id   path string true "点评 ID"
+// @Param        If-Match header string false "点评的 ETag，版本不符时拒绝操作"
+// @Param        body body object{response=string} false "处理回复"
+// @Success      200  {object} models.Review "处理成功"
+// @Failure      400  {object} object{error=string} "无效的点评 ID、请求参数错误或点评不在申诉中"
+// @Failure      404  {object} object{error=string} "点评不存在"
+// @Failure      409  {object} object{error=string} "点评已被其他管理员认领或已被并发修改"
+// @Failure      412  {object} object{error=string} "If-Match 与当前版本不符"
+// @Security     ApiKeyAuth
+// @Router       /admin/reviews/{id}/appeal/accept [put]
+func (h *ReviewAdminHandler) AcceptAppeal(c *gin.Context) {
+	h.resolveAppeal(c, true)
+}
+
+// @Summary      驳回申诉
+// @Description  维持原驳回决定，点评回到“已拒绝”状态，可附带回复。
+// @Tags         管理
+// @Accept       json
+// @Produce      json
+// @Param        id   path string true "点评 ID"
+// @Param        If-Match header string false "点评的 ETag，版本不符时拒绝操作"
+// @Param        body body object{response=string} false "处理回复"
+// @Success      200  {object} models.Review "处理成功"
+// @Failure      400  {object} object{error=string} "无效的点评 ID、请求参数错误或点评不在申诉中"
+// @Failure      404  {object} object{error=string} "点评不存在"
+// @Failure      409  {object} object{error=string} "点评已被其他管理员认领或已被并发修改"
+// @Failure      412  {object} object{error=string} "If-Match 与当前版本不符"
+// @Security     ApiKeyAuth
+// @Router       /admin/reviews/{id}/appeal/deny [put]
+func (h *ReviewAdminHandler) DenyAppeal(c *gin.Context) {
+	h.resolveAppeal(c, false)
+}
+
+func (h *ReviewAdminHandler) resolveAppeal(c *gin.Context, accept bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return
+	}
+
+	review, err := h.reviews.Get(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
+		return
+	}
+	if !handlers.CheckIfMatch(c, review) {
+		return
+	}
+
+	var req struct {
+		Response string `json:"response"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+			return
+		}
+	}
+
+	adminID := c.MustGet("user_id").(uuid.UUID)
+	if err := h.reviews.ResolveAppeal(review, adminID, accept, req.Response); err != nil {
+		c.JSON(decisionErrorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	handlers.SetReviewETag(c, review)
+	c.JSON(http.StatusOK, review)
+}
+
+// @Summary      点评变更对比
+// @Description  返回待审核内容的逐字段变更：已通过点评存在待审核修改时，对比公开内容与修改内容；否则返回最近一次修改记录的变更。
+// @Tags         管理
+// @Produce      json
+// @Param        id path string true "点评 ID"
+// @Success      200 {object} services.ReviewDiff
+// @Failure      400 {object} object{error=string} "无效的点评 ID"
+// @Failure      404 {object} object{error=string} "点评不存在"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /admin/reviews/{id}/diff [get]
+func (h *ReviewAdminHandler) Diff(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return
+	}
+
+	review, err := h.reviews.Get(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
+		return
+	}
+
+	diff, err := h.reviews.Diff(review)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
 // @Summary      批准点评
-// @Description  将指定 ID 的点评状态标记为“已批准”。
+// @Description  将指定 ID 的点评状态标记为“已批准”；若为已通过点评的待审核修改，则以修改内容替换公开内容。
 // @Tags         管理
 // @Produce      json
 // @Param        id path string true "点评 ID"
+// @Param        If-Match header string false "点评的 ETag，版本不符时拒绝操作"
 // @Success      200 {object} models.Review "批准成功"
 // @Failure      400 {object} object{error=string} "无效的点评 ID 或状态错误"
 // @Failure      404 {object} object{error=string} "点评不存在"
+// @Failure      409 {object} object{error=string} "点评已被其他管理员认领或已被并发修改"
+// @Failure      412 {object} object{error=string} "If-Match 与当前版本不符"
 // @Security     ApiKeyAuth
 // @Router       /admin/reviews/{id}/approve [put]
 func (h *ReviewAdminHandler) Approve(c *gin.Context) {
@@ -72,25 +231,33 @@ func (h *ReviewAdminHandler) Approve(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
 		return
 	}
+	if !handlers.CheckIfMatch(c, review) {
+		return
+	}
 
-	if err := h.reviews.Approve(review); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	adminID := c.MustGet("user_id").(uuid.UUID)
+	if err := h.reviews.Approve(review, workflow.ActorAdmin, adminID); err != nil {
+		c.JSON(decisionErrorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
 		return
 	}
 
+	handlers.SetReviewETag(c, review)
 	c.JSON(http.StatusOK, review)
 }
 
 // @Summary      拒绝点评
-// @Description  将指定 ID 的点评状态标记为“已拒绝”，并记录原因。
+// @Description  将指定 ID 的点评状态标记为“已拒绝”，并记录原因；可通过 code 选择驳回模板，此时原因为可选的补充说明，缺省为模板文案。若为已通过点评的待审核修改，则仅丢弃该修改，原内容保持公开。
 // @Tags         管理
 // @Accept       json
 // @Produce      json
 // @Param        id   path string true "点评 ID"
-// @Param        body body object{reason=string} true "拒绝原因"
+// @Param        If-Match header string false "点评的 ETag，版本不符时拒绝操作"
+// @Param        body body object{code=string,reason=string} true "驳回模板代码与拒绝原因"
 // @Success      200  {object} models.Review "拒绝成功"
-// @Failure      400  {object} object{error=string} "无效的点评 ID 或请求参数错误"
+// @Failure      400  {object} object{error=string} "无效的点评 ID、请求参数错误或驳回模板不存在"
 // @Failure      404  {object} object{error=string} "点评不存在"
+// @Failure      409  {object} object{error=string} "点评已被其他管理员认领或已被并发修改"
+// @Failure      412  {object} object{error=string} "If-Match 与当前版本不符"
 // @Security     ApiKeyAuth
 // @Router       /admin/reviews/{id}/reject [put]
 func (h *ReviewAdminHandler) Reject(c *gin.Context) {
@@ -105,8 +272,12 @@ func (h *ReviewAdminHandler) Reject(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
 		return
 	}
+	if !handlers.CheckIfMatch(c, review) {
+		return
+	}
 
 	var req struct {
+		Code   string `json:"code"`
 		Reason string `json:"reason"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -114,22 +285,29 @@ func (h *ReviewAdminHandler) Reject(c *gin.Context) {
 		return
 	}
 
-	if err := h.reviews.Reject(review, req.Reason); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	adminID := c.MustGet("user_id").(uuid.UUID)
+	if err := h.reviews.Reject(review, workflow.ActorAdmin, adminID, services.RejectInput{Code: req.Code, Reason: req.Reason}); err != nil {
+		c.JSON(decisionErrorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
 		return
 	}
 
+	handlers.SetReviewETag(c, review)
 	c.JSON(http.StatusOK, review)
 }
 
 // @Summary      删除点评
-// @Description  永久删除指定 ID 的点评及其关联的图片。
+// @Description  将指定 ID 的点评移入回收站，可附带删除原因。回收站中的点评可恢复，保留期满后连同图片永久删除。
 // @Tags         管理
+// @Accept       json
 // @Produce      json
-// @Param        id path string true "点评 ID"
+// @Param        id   path string true "点评 ID"
+// @Param        If-Match header string false "点评的 ETag，版本不符时拒绝操作"
+// @Param        body body object{reason=string} false "删除原因"
 // @Success      204 "删除成功"
-// @Failure      400 {object} object{error=string} "无效的点评 ID"
+// @Failure      400 {object} object{error=string} "无效的点评 ID 或请求参数错误"
 // @Failure      404 {object} object{error=string} "点评不存在"
+// @Failure      409 {object} object{error=string} "点评已被其他管理员认领或已被并发修改"
+// @Failure      412 {object} object{error=string} "If-Match 与当前版本不符"
 // @Failure      500 {object} object{error=string} "服务器内部错误"
 // @Security     ApiKeyAuth
 // @Router       /admin/reviews/{id} [delete]
@@ -145,15 +323,313 @@ func (h *ReviewAdminHandler) Delete(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
 		return
 	}
+	if !handlers.CheckIfMatch(c, review) {
+		return
+	}
 
-	if err := h.reviews.DeleteReview(c.Request.Context(), review); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+			return
+		}
+	}
+
+	adminID := c.MustGet("user_id").(uuid.UUID)
+	if err := h.reviews.DeleteReview(review, workflow.ActorAdmin, adminID, req.Reason); err != nil {
+		c.JSON(decisionErrorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
 
+// @Summary      回收站点评列表
+// @Description  获取已删除（位于回收站中）的点评，按删除时间倒序，支持分页与搜索。
+// @Tags         管理
+// @Produce      json
+// @Param        page      query int    false "页码" default(1)
+// @Param        page_size query int    false "每页数量" default(10)
+// @Param        query     query string false "搜索关键词"
+// @Success      200 {object} services.ReviewListResult
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /admin/reviews/trash [get]
+func (h *ReviewAdminHandler) Trash(c *gin.Context) {
+	filters := services.ListFilters{
+		Page:     mustAtoi(c.DefaultQuery("page", "1")),
+		PageSize: mustAtoi(c.DefaultQuery("page_size", "10")),
+		Query:    strings.TrimSpace(c.Query("query")),
+	}
+
+	result, err := h.reviews.ListTrash(filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary      恢复点评
+// @Description  将回收站中的点评恢复为删除前的状态；已通过的点评重新计入地点评分。
+// @Tags         管理
+// @Produce      json
+// @Param        id path string true "点评 ID"
+// @Success      200 {object} models.Review "恢复成功"
+// @Failure      400 {object} object{error=string} "无效的点评 ID"
+// @Failure      404 {object} object{error=string} "回收站中不存在该点评"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /admin/reviews/{id}/restore [post]
+func (h *ReviewAdminHandler) Restore(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return
+	}
+
+	review, err := h.reviews.GetDeleted(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "review not found in trash"})
+		return
+	}
+
+	adminID := c.MustGet("user_id").(uuid.UUID)
+	if err := h.reviews.RestoreReview(review, adminID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// @Summary      认领点评
+// @Description  认领一条等待审核的点评（待审核、带待审核修改或申诉中），在有效期内其他管理员无法对其作出审核决定。重复认领会延长有效期。
+// @Tags         管理
+// @Produce      json
+// @Param        id path string true "点评 ID"
+// @Success      200 {object} models.Review "认领成功"
+// @Failure      400 {object} object{error=string} "无效的点评 ID"
+// @Failure      404 {object} object{error=string} "点评不存在"
+// @Failure      409 {object} object{error=string} "点评已被其他管理员认领或无需审核"
+// @Security     ApiKeyAuth
+// @Router       /admin/reviews/{id}/claim [post]
+func (h *ReviewAdminHandler) Claim(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return
+	}
+
+	review, err := h.reviews.Get(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
+		return
+	}
+
+	adminID := c.MustGet("user_id").(uuid.UUID)
+	if err := h.reviews.Claim(review, adminID); err != nil {
+		c.JSON(decisionErrorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// @Summary      释放认领
+// @Description  释放自己对点评的认领，使其他管理员可以处理。
+// @Tags         管理
+// @Produce      json
+// @Param        id path string true "点评 ID"
+// @Success      200 {object} models.Review "释放成功"
+// @Failure      400 {object} object{error=string} "无效的点评 ID"
+// @Failure      404 {object} object{error=string} "点评不存在"
+// @Failure      409 {object} object{error=string} "点评已被其他管理员认领"
+// @Security     ApiKeyAuth
+// @Router       /admin/reviews/{id}/claim [delete]
+func (h *ReviewAdminHandler) ReleaseClaim(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return
+	}
+
+	review, err := h.reviews.Get(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
+		return
+	}
+
+	adminID := c.MustGet("user_id").(uuid.UUID)
+	if err := h.reviews.ReleaseClaim(review, adminID); err != nil {
+		c.JSON(decisionErrorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// decisionErrorStatus maps claim and version conflicts to 409 and other
+// errors to fallback.
+func decisionErrorStatus(err error, fallback int) int {
+	if errors.Is(err, common.ErrReviewClaimed) || errors.Is(err, common.ErrReviewNotClaimable) ||
+		errors.Is(err, common.ErrVersionConflict) {
+		return http.StatusConflict
+	}
+	return fallback
+}
+
+type bulkRequest struct {
+	IDs    []uuid.UUID `json:"ids"`
+	Code   string      `json:"code"`
+	Reason string      `json:"reason"`
+}
+
+// @Summary      批量批准点评
+// @Description  按 ID 列表逐条批准点评（规则同单条批准），每条独立提交，返回逐条结果。
+// @Tags         管理
+// @Accept       json
+// @Produce      json
+// @Param        body body object{ids=[]string} true "点评 ID 列表（最多 100 个）"
+// @Success      200 {object} services.BulkResult
+// @Failure      400 {object} object{error=string} "请求参数错误"
+// @Security     ApiKeyAuth
+// @Router       /admin/reviews/bulk/approve [post]
+func (h *ReviewAdminHandler) BulkApprove(c *gin.Context) {
+	h.bulk(c, func(req bulkRequest, adminID uuid.UUID) (services.BulkResult, error) {
+		return h.reviews.BulkApprove(req.IDs, workflow.ActorAdmin, adminID)
+	})
+}
+
+// @Summary      批量驳回点评
+// @Description  按 ID 列表逐条驳回点评并使用同一驳回模板与原因（规则同单条驳回），每条独立提交，返回逐条结果。
+// @Tags         管理
+// @Accept       json
+// @Produce      json
+// @Param        body body object{ids=[]string,code=string,reason=string} true "点评 ID 列表（最多 100 个）、驳回模板代码与驳回原因"
+// @Success      200 {object} services.BulkResult
+// @Failure      400 {object} object{error=string} "请求参数错误或驳回模板不存在"
+// @Security     ApiKeyAuth
+// @Router       /admin/reviews/bulk/reject [post]
+func (h *ReviewAdminHandler) BulkReject(c *gin.Context) {
+	h.bulk(c, func(req bulkRequest, adminID uuid.UUID) (services.BulkResult, error) {
+		return h.reviews.BulkReject(req.IDs, workflow.ActorAdmin, adminID, services.RejectInput{Code: req.Code, Reason: req.Reason})
+	})
+}
+
+// @Summary      批量删除点评
+// @Description  按 ID 列表逐条将点评移入回收站并记录同一原因，每条独立提交，返回逐条结果。
+// @Tags         管理
+// @Accept       json
+// @Produce      json
+// @Param        body body object{ids=[]string,reason=string} true "点评 ID 列表（最多 100 个）与删除原因"
+// @Success      200 {object} services.BulkResult
+// @Failure      400 {object} object{error=string} "请求参数错误"
+// @Security     ApiKeyAuth
+// @Router       /admin/reviews/bulk/delete [post]
+func (h *ReviewAdminHandler) BulkDelete(c *gin.Context) {
+	h.bulk(c, func(req bulkRequest, adminID uuid.UUID) (services.BulkResult, error) {
+		return h.reviews.BulkDelete(req.IDs, workflow.ActorAdmin, adminID, req.Reason)
+	})
+}
+
+func (h *ReviewAdminHandler) bulk(c *gin.Context, run func(bulkRequest, uuid.UUID) (services.BulkResult, error)) {
+	var req bulkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	adminID := c.MustGet("user_id").(uuid.UUID)
+	result, err := run(req, adminID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary      审核操作日志
+// @Description  查询管理员与系统对点评执行的审核操作（通过、驳回、删除、恢复、申诉处理等），按时间倒序分页返回。
+// @Tags         管理
+// @Produce      json
+// @Param        page      query int    false "页码" default(1)
+// @Param        page_size query int    false "每页数量" default(10)
+// @Param        review_id query string false "点评 ID"
+// @Param        author_id query string false "点评作者 ID"
+// @Param        actor_id  query string false "操作人 ID"
+// @Param        action    query string false "操作类型，如 approve、reject、delete"
+// @Param        from      query string false "起始时间（RFC3339 或 YYYY-MM-DD）"
+// @Param        to        query string false "截止时间（RFC3339 或 YYYY-MM-DD，日期包含当天）"
+// @Success      200 {object} services.ModerationActionListResult
+// @Failure      400 {object} object{error=string} "查询参数错误"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /admin/moderation-actions [get]
+func (h *ReviewAdminHandler) ModerationActions(c *gin.Context) {
+	filters := services.ModerationActionFilters{
+		Page:     mustAtoi(c.DefaultQuery("page", "1")),
+		PageSize: mustAtoi(c.DefaultQuery("page_size", "10")),
+		Action:   strings.TrimSpace(c.Query("action")),
+	}
+
+	for param, target := range map[string]**uuid.UUID{
+		"review_id": &filters.ReviewID,
+		"author_id": &filters.AuthorID,
+		"actor_id":  &filters.ActorID,
+	} {
+		raw := c.Query(param)
+		if raw == "" {
+			continue
+		}
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param})
+			return
+		}
+		*target = &id
+	}
+
+	var err error
+	if filters.From, err = parseTimeParam(c.Query("from"), false); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from"})
+		return
+	}
+	if filters.To, err = parseTimeParam(c.Query("to"), true); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to"})
+		return
+	}
+
+	result, err := h.reviews.ListModerationActions(filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// parseTimeParam accepts RFC3339 timestamps or plain dates. A plain date used
+// as an upper bound covers the whole day. Timestamps are converted to local
+// time, in which SQLite stores and compares created_at as text.
+func parseTimeParam(val string, endOfDay bool) (time.Time, error) {
+	if val == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, val); err == nil {
+		return t.In(time.Local), nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", val, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t, nil
+}
+
 func mustAtoi(val string) int {
 	n, _ := strconv.Atoi(val)
 	return n
@@ -0,0 +1,198 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
+	"github.com/hdu-dp/backend/internal/models"
+	"github.com/hdu-dp/backend/internal/services"
+)
+
+// SensitiveWordAdminHandler exposes sensitive word list management for
+// administrators.
+type SensitiveWordAdminHandler struct {
+	words *services.SensitiveWordService
+}
+
+// NewSensitiveWordAdminHandler constructs a new handler.
+func NewSensitiveWordAdminHandler(words *services.SensitiveWordService) *SensitiveWordAdminHandler {
+	return &SensitiveWordAdminHandler{words: words}
+}
+
+type sensitiveWordRequest struct {
+	Word     string                     `json:"word"`
+	Variants []string                   `json:"variants"`
+	Category string                     `json:"category"`
+	Action   models.SensitiveWordAction `json:"action"`
+}
+
+func (r sensitiveWordRequest) input() services.SensitiveWordInput {
+	return services.SensitiveWordInput{
+		Word:     r.Word,
+		Variants: r.Variants,
+		Category: r.Category,
+		Action:   r.Action,
+	}
+}
+
+// @Summary      敏感词列表
+// @Description  分页获取敏感词，按词排序，支持按分类、处理方式筛选与搜索。
+// @Tags         管理
+// @Produce      json
+// @Param        page      query int    false "页码" default(1)
+// @Param        page_size query int    false "每页数量" default(10)
+// @Param        category  query string false "分类"
+// @Param        action    query string false "处理方式 (block, mask, flag)" enums(block, mask, flag)
+// @Param        query     query string false "按词或变体搜索"
+// @Success      200 {object} services.SensitiveWordListResult
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /admin/sensitive-words [get]
+func (h *SensitiveWordAdminHandler) List(c *gin.Context) {
+	result, err := h.words.List(services.SensitiveWordListFilters{
+		Page:     mustAtoi(c.DefaultQuery("page", "1")),
+		PageSize: mustAtoi(c.DefaultQuery("page_size", "10")),
+		Category: c.Query("category"),
+		Action:   c.Query("action"),
+		Query:    strings.TrimSpace(c.Query("query")),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary      新增敏感词
+// @Description  新增敏感词，立即对之后提交的点评生效。variants 为同义拼写（如拼音、缩写）；action 为 block（拒绝提交）、mask（以 * 替换）或 flag（标记给审核员），缺省为 flag。
+// @Tags         管理
+// @Accept       json
+// @Produce      json
+// @Param        body body object{word=string,variants=[]string,category=string,action=string} true "敏感词信息"
+// @Success      201  {object} models.SensitiveWord "创建成功"
+// @Failure      400  {object} object{error=string} "请求参数错误"
+// @Failure      409  {object} object{error=string} "敏感词已存在"
+// @Security     ApiKeyAuth
+// @Router       /admin/sensitive-words [post]
+func (h *SensitiveWordAdminHandler) Create(c *gin.Context) {
+	var req sensitiveWordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	word, err := h.words.Create(req.input())
+	if err != nil {
+		c.JSON(sensitiveWordErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, word)
+}
+
+// @Summary      更新敏感词
+// @Description  修改指定敏感词的拼写、变体、分类与处理方式。
+// @Tags         管理
+// @Accept       json
+// @Produce      json
+// @Param        id   path string true "敏感词 ID"
+// @Param        body body object{word=string,variants=[]string,category=string,action=string} true "敏感词信息"
+// @Success      200  {object} models.SensitiveWord "更新成功"
+// @Failure      400  {object} object{error=string} "无效的敏感词 ID 或请求参数错误"
+// @Failure      404  {object} object{error=string} "敏感词不存在"
+// @Failure      409  {object} object{error=string} "敏感词已存在"
+// @Security     ApiKeyAuth
+// @Router       /admin/sensitive-words/{id} [put]
+func (h *SensitiveWordAdminHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sensitive word id"})
+		return
+	}
+
+	word, err := h.words.Get(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "sensitive word not found"})
+		return
+	}
+
+	var req sensitiveWordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	if err := h.words.Update(word, req.input()); err != nil {
+		c.JSON(sensitiveWordErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, word)
+}
+
+// @Summary      删除敏感词
+// @Description  从敏感词表中删除指定词。
+// @Tags         管理
+// @Produce      json
+// @Param        id path string true "敏感词 ID"
+// @Success      204 "删除成功"
+// @Failure      400 {object} object{error=string} "无效的敏感词 ID"
+// @Failure      404 {object} object{error=string} "敏感词不存在"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /admin/sensitive-words/{id} [delete]
+func (h *SensitiveWordAdminHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sensitive word id"})
+		return
+	}
+
+	word, err := h.words.Get(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "sensitive word not found"})
+		return
+	}
+
+	if err := h.words.Delete(word); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      测试敏感词过滤
+// @Description  用当前敏感词表检测一段文本，返回处理后的文本与命中的拒绝词、标记词，不保存任何数据。
+// @Tags         管理
+// @Accept       json
+// @Produce      json
+// @Param        body body object{text=string} true "待检测文本"
+// @Success      200  {object} services.ScreenResult
+// @Failure      400  {object} object{error=string} "请求参数错误"
+// @Security     ApiKeyAuth
+// @Router       /admin/sensitive-words/test [post]
+func (h *SensitiveWordAdminHandler) Test(c *gin.Context) {
+	var req struct {
+		Text string `json:"text"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.words.Screen(req.Text))
+}
+
+// sensitiveWordErrorStatus maps duplicate words to 409 and validation
+// failures to 400.
+func sensitiveWordErrorStatus(err error) int {
+	if errors.Is(err, common.ErrSensitiveWordExists) {
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
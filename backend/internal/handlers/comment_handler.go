@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
+	"github.com/hdu-dp/backend/internal/models"
+	"github.com/hdu-dp/backend/internal/services"
+)
+
+// CommentHandler exposes comments on published reviews.
+type CommentHandler struct {
+	comments *services.CommentService
+	reviews  *services.ReviewService
+}
+
+// NewCommentHandler constructs a CommentHandler.
+func NewCommentHandler(comments *services.CommentService, reviews *services.ReviewService) *CommentHandler {
+	return &CommentHandler{comments: comments, reviews: reviews}
+}
+
+// @Summary      点评评论列表
+// @Description  分页获取已发布点评下已通过审核的顶层评论，按发布时间先后排序，每条评论附带已通过审核的回复（replies）。
+// @Tags         点评
+// @Produce      json
+// @Param        id        path  string true  "点评 ID"
+// @Param        page      query int    false "页码" default(1)
+// @Param        page_size query int    false "每页数量" default(10)
+// @Success      200 {object} services.CommentListResult
+// @Failure      400 {object} object{error=string} "无效的点评 ID"
+// @Failure      404 {object} object{error=string} "点评不存在"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Router       /reviews/{id}/comments [get]
+func (h *CommentHandler) List(c *gin.Context) {
+	review, ok := h.loadReview(c)
+	if !ok {
+		return
+	}
+	if review.Status != models.ReviewStatusApproved {
+		c.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	result, err := h.comments.ListByReview(review.ID, services.CommentFilters{Page: page, PageSize: pageSize})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary      发表评论
+// @Description  在已发布的点评下发表评论，或通过 parent_id 回复已通过审核的评论。回复只有一层：回复一条回复时会挂到其所属的顶层评论下。评论与点评一样经过敏感词过滤和自动预审，status 为 approved 时立即公开，pending 时等待管理员审核，rejected 时 rejection_reason 说明原因。
+// @Tags         点评
+// @Accept       json
+// @Produce      json
+// @Param        id   path string true "点评 ID"
+// @Param        body body object{body=string,parent_id=string} true "评论内容（不超过 1000 字）与被回复的评论 ID"
+// @Success      201 {object} models.Comment "发表成功"
+// @Failure      400 {object} object{error=string} "无效的点评 ID、请求参数错误或包含禁用词"
+// @Failure      404 {object} object{error=string} "点评不存在"
+// @Failure      409 {object} object{error=string} "点评未发布"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /reviews/{id}/comments [post]
+func (h *CommentHandler) Create(c *gin.Context) {
+	review, ok := h.loadReview(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Body     string     `json:"body"`
+		ParentID *uuid.UUID `json:"parent_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	userID := c.MustGet("user_id").(uuid.UUID)
+	comment, err := h.comments.Create(review, userID, services.CommentInput{Body: req.Body, ParentID: req.ParentID})
+	if err != nil {
+		writeCommentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// @Summary      修改评论
+// @Description  作者修改自己的评论。修改后的内容重新经过敏感词过滤和自动预审，未自动通过时评论在管理员审核前不再公开；返回的 status 与 rejection_reason 给出审核结果。
+// @Tags         点评
+// @Accept       json
+// @Produce      json
+// @Param        id         path string true "点评 ID"
+// @Param        comment_id path string true "评论 ID"
+// @Param        body       body object{body=string} true "新的评论内容"
+// @Success      200 {object} models.Comment "修改成功"
+// @Failure      400 {object} object{error=string} "无效的 ID、请求参数错误或包含禁用词"
+// @Failure      403 {object} object{error=string} "不是评论作者"
+// @Failure      404 {object} object{error=string} "评论不存在"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /reviews/{id}/comments/{comment_id} [put]
+func (h *CommentHandler) Update(c *gin.Context) {
+	comment, ok := h.loadOwnComment(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Body string `json:"body"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	if err := h.comments.Update(comment, req.Body); err != nil {
+		writeCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, comment)
+}
+
+// @Summary      删除评论
+// @Description  作者删除自己的评论，顶层评论的回复一并删除。
+// @Tags         点评
+// @Param        id         path string true "点评 ID"
+// @Param        comment_id path string true "评论 ID"
+// @Success      204 "删除成功"
+// @Failure      400 {object} object{error=string} "无效的 ID"
+// @Failure      403 {object} object{error=string} "不是评论作者"
+// @Failure      404 {object} object{error=string} "评论不存在"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /reviews/{id}/comments/{comment_id} [delete]
+func (h *CommentHandler) Delete(c *gin.Context) {
+	comment, ok := h.loadOwnComment(c)
+	if !ok {
+		return
+	}
+
+	if err := h.comments.Delete(comment); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      我的评论列表
+// @Description  获取当前认证用户发表的评论，包括待审核和被驳回的评论，按发表时间倒序排列。被驳回的评论通过 rejection_reason 说明原因。
+// @Tags         点评
+// @Produce      json
+// @Param        status    query string false "按审核状态筛选" enums(pending, approved, rejected)
+// @Param        page      query int    false "页码" default(1)
+// @Param        page_size query int    false "每页数量" default(10)
+// @Success      200 {object} services.CommentListResult
+// @Failure      400 {object} object{error=string} "无效的审核状态"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /comments/me [get]
+func (h *CommentHandler) MyComments(c *gin.Context) {
+	status := models.CommentStatus(c.Query("status"))
+	switch status {
+	case "", models.CommentStatusPending, models.CommentStatusApproved, models.CommentStatusRejected:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+		return
+	}
+
+	userID := c.MustGet("user_id").(uuid.UUID)
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	result, err := h.comments.ListByAuthor(userID, services.CommentFilters{Page: page, PageSize: pageSize, Status: status})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// writeCommentError maps errors from creating or editing a comment.
+func writeCommentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, common.ErrInvalidComment) || errors.Is(err, common.ErrContentBlocked):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, common.ErrReviewNotCommentable):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+func (h *CommentHandler) loadReview(c *gin.Context) (*models.Review, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return nil, false
+	}
+
+	review, err := h.reviews.Get(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
+		return nil, false
+	}
+	return review, true
+}
+
+func (h *CommentHandler) loadOwnComment(c *gin.Context) (*models.Comment, bool) {
+	reviewID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return nil, false
+	}
+	id, err := uuid.Parse(c.Param("comment_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment id"})
+		return nil, false
+	}
+
+	comment, err := h.comments.GetOnReview(reviewID, id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "comment not found"})
+		return nil, false
+	}
+
+	userID := c.MustGet("user_id").(uuid.UUID)
+	if comment.AuthorID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not owner"})
+		return nil, false
+	}
+	return comment, true
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hdu-dp/backend/internal/models"
+)
+
+// ReviewETag returns the entity tag of a review's current state. It starts
+// with the version, followed by the vote tallies, which change without
+// bumping the version, and the viewer's favorite flag when one is set.
+func ReviewETag(review *models.Review) string {
+	tag := fmt.Sprintf("%d-%d-%d", review.Version, review.HelpfulCount, review.UnhelpfulCount)
+	if review.Favorited != nil {
+		if *review.Favorited {
+			tag += "-f"
+		} else {
+			tag += "-n"
+		}
+	}
+	return `"` + tag + `"`
+}
+
+// SetReviewETag sets the ETag response header for a review.
+func SetReviewETag(c *gin.Context, review *models.Review) {
+	c.Header("ETag", ReviewETag(review))
+}
+
+// CheckIfMatch enforces an If-Match request header against the review's
+// current version, responding 412 and returning false on mismatch. Only the
+// version part of the tags is compared, so votes cast since the client read
+// the review do not block its changes. Requests without the header are
+// allowed.
+func CheckIfMatch(c *gin.Context, review *models.Review) bool {
+	header := c.GetHeader("If-Match")
+	if header == "" || matchesVersion(header, review.Version) {
+		return true
+	}
+	SetReviewETag(c, review)
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": "review has been modified"})
+	return false
+}
+
+// matchesETag reports whether a comma separated If-Match/If-None-Match value
+// lists etag or is "*".
+func matchesETag(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesVersion reports whether a comma separated If-Match value lists a
+// review tag of the given version or is "*".
+func matchesVersion(header string, version int64) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.Trim(strings.TrimSpace(candidate), `"`)
+		if candidate == "*" {
+			return true
+		}
+		prefix, _, _ := strings.Cut(candidate, "-")
+		if v, err := strconv.ParseInt(prefix, 10, 64); err == nil && v == version {
+			return true
+		}
+	}
+	return false
+}
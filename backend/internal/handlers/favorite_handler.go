@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
+	"github.com/hdu-dp/backend/internal/models"
+	"github.com/hdu-dp/backend/internal/services"
+)
+
+// FavoriteHandler lets users save reviews and places for later.
+type FavoriteHandler struct {
+	favorites *services.FavoriteService
+	reviews   *services.ReviewService
+	places    *services.PlaceService
+}
+
+// NewFavoriteHandler constructs a FavoriteHandler.
+func NewFavoriteHandler(favorites *services.FavoriteService, reviews *services.ReviewService, places *services.PlaceService) *FavoriteHandler {
+	return &FavoriteHandler{favorites: favorites, reviews: reviews, places: places}
+}
+
+// @Summary      我的收藏
+// @Description  分页获取当前用户收藏的点评与地点，按收藏时间倒序排列。点评收藏附带 review，地点收藏附带 place；已下架或删除的点评不列出。
+// @Tags         用户
+// @Produce      json
+// @Param        page      query int    false "页码" default(1)
+// @Param        page_size query int    false "每页数量" default(10)
+// @Param        type      query string false "收藏类型 (review, place)，缺省时返回全部" enums(review, place)
+// @Success      200 {object} services.FavoriteListResult
+// @Failure      400 {object} object{error=string} "无效的收藏类型"
+// @Security     ApiKeyAuth
+// @Router       /favorites [get]
+func (h *FavoriteHandler) List(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+
+	userID := c.MustGet("user_id").(uuid.UUID)
+	result, err := h.favorites.List(userID, services.FavoriteFilters{
+		Page:     page,
+		PageSize: pageSize,
+		Type:     models.FavoriteType(c.Query("type")),
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	for _, favorite := range result.Data {
+		if favorite.Review != nil {
+			hideModerationDetails(favorite.Review)
+		}
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary      收藏点评
+// @Description  收藏已发布的点评，重复收藏不产生变化。
+// @Tags         点评
+// @Produce      json
+// @Param        id path string true "点评 ID"
+// @Success      200 {object} object{favorited=bool} "收藏成功"
+// @Failure      400 {object} object{error=string} "无效的点评 ID"
+// @Failure      404 {object} object{error=string} "点评不存在"
+// @Failure      409 {object} object{error=string} "点评未发布"
+// @Security     ApiKeyAuth
+// @Router       /reviews/{id}/favorite [put]
+func (h *FavoriteHandler) FavoriteReview(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return
+	}
+
+	review, err := h.reviews.Get(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
+		return
+	}
+
+	userID := c.MustGet("user_id").(uuid.UUID)
+	if err := h.favorites.FavoriteReview(userID, review); err != nil {
+		if errors.Is(err, common.ErrReviewNotFavoritable) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"favorited": true})
+}
+
+// @Summary      取消收藏点评
+// @Description  将点评移出收藏，未收藏时不产生变化。
+// @Tags         点评
+// @Produce      json
+// @Param        id path string true "点评 ID"
+// @Success      200 {object} object{favorited=bool} "取消成功"
+// @Failure      400 {object} object{error=string} "无效的点评 ID"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /reviews/{id}/favorite [delete]
+func (h *FavoriteHandler) UnfavoriteReview(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return
+	}
+
+	userID := c.MustGet("user_id").(uuid.UUID)
+	if err := h.favorites.UnfavoriteReview(userID, id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"favorited": false})
+}
+
+// @Summary      收藏地点
+// @Description  收藏地点，重复收藏不产生变化。
+// @Tags         地点
+// @Produce      json
+// @Param        id path string true "地点 ID"
+// @Success      200 {object} object{favorited=bool} "收藏成功"
+// @Failure      400 {object} object{error=string} "无效的地点 ID"
+// @Failure      404 {object} object{error=string} "地点不存在"
+// @Security     ApiKeyAuth
+// @Router       /places/{id}/favorite [put]
+func (h *FavoriteHandler) FavoritePlace(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid place id"})
+		return
+	}
+
+	place, err := h.places.Get(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "place not found"})
+		return
+	}
+
+	userID := c.MustGet("user_id").(uuid.UUID)
+	if err := h.favorites.FavoritePlace(userID, place); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"favorited": true})
+}
+
+// @Summary      取消收藏地点
+// @Description  将地点移出收藏，未收藏时不产生变化。
+// @Tags         地点
+// @Produce      json
+// @Param        id path string true "地点 ID"
+// @Success      200 {object} object{favorited=bool} "取消成功"
+// @Failure      400 {object} object{error=string} "无效的地点 ID"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /places/{id}/favorite [delete]
+func (h *FavoriteHandler) UnfavoritePlace(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid place id"})
+		return
+	}
+
+	userID := c.MustGet("user_id").(uuid.UUID)
+	if err := h.favorites.UnfavoritePlace(userID, id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"favorited": false})
+}
+
+// viewerID returns the signed-in user on routes with optional
+// authentication, or nil for anonymous requests.
+func viewerID(c *gin.Context) *uuid.UUID {
+	if id, ok := c.Get("user_id"); ok {
+		if userID, ok := id.(uuid.UUID); ok {
+			return &userID
+		}
+	}
+	return nil
+}
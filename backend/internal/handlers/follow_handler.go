@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
+	"github.com/hdu-dp/backend/internal/services"
+)
+
+// FollowHandler exposes following other users and the feed of their reviews.
+type FollowHandler struct {
+	follows   *services.FollowService
+	favorites *services.FavoriteService
+}
+
+// NewFollowHandler constructs a FollowHandler.
+func NewFollowHandler(follows *services.FollowService, favorites *services.FavoriteService) *FollowHandler {
+	return &FollowHandler{follows: follows, favorites: favorites}
+}
+
+// @Summary      关注用户
+// @Description  关注其他用户，其已发布的点评会出现在关注动态中。重复关注不产生变化，不能关注自己。
+// @Tags         用户
+// @Produce      json
+// @Param        id path string true "用户 ID"
+// @Success      200 {object} object{following=bool} "关注成功"
+// @Failure      400 {object} object{error=string} "无效的用户 ID 或关注自己"
+// @Failure      404 {object} object{error=string} "用户不存在"
+// @Security     ApiKeyAuth
+// @Router       /users/{id}/follow [put]
+func (h *FollowHandler) Follow(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	followee, err := h.follows.GetUser(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		return
+	}
+
+	userID := c.MustGet("user_id").(uuid.UUID)
+	if err := h.follows.Follow(userID, followee); err != nil {
+		if errors.Is(err, common.ErrSelfFollow) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"following": true})
+}
+
+// @Summary      取消关注
+// @Description  取消关注用户，未关注时不产生变化。
+// @Tags         用户
+// @Produce      json
+// @Param        id path string true "用户 ID"
+// @Success      200 {object} object{following=bool} "取消成功"
+// @Failure      400 {object} object{error=string} "无效的用户 ID"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /users/{id}/follow [delete]
+func (h *FollowHandler) Unfollow(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	userID := c.MustGet("user_id").(uuid.UUID)
+	if err := h.follows.Unfollow(userID, id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"following": false})
+}
+
+// @Summary      粉丝列表
+// @Description  分页获取关注该用户的用户（follower），按关注时间倒序排列。
+// @Tags         用户
+// @Produce      json
+// @Param        id        path  string true  "用户 ID"
+// @Param        page      query int    false "页码" default(1)
+// @Param        page_size query int    false "每页数量" default(10)
+// @Success      200 {object} services.FollowListResult
+// @Failure      400 {object} object{error=string} "无效的用户 ID"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /users/{id}/followers [get]
+func (h *FollowHandler) Followers(c *gin.Context) {
+	h.list(c, h.follows.Followers)
+}
+
+// @Summary      关注列表
+// @Description  分页获取该用户关注的用户（followee），按关注时间倒序排列。
+// @Tags         用户
+// @Produce      json
+// @Param        id        path  string true  "用户 ID"
+// @Param        page      query int    false "页码" default(1)
+// @Param        page_size query int    false "每页数量" default(10)
+// @Success      200 {object} services.FollowListResult
+// @Failure      400 {object} object{error=string} "无效的用户 ID"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /users/{id}/following [get]
+func (h *FollowHandler) Following(c *gin.Context) {
+	h.list(c, h.follows.Following)
+}
+
+func (h *FollowHandler) list(c *gin.Context, op func(uuid.UUID, services.FollowFilters) (services.FollowListResult, error)) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	result, err := op(id, services.FollowFilters{Page: page, PageSize: pageSize})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary      关注动态
+// @Description  获取所关注用户已发布的点评，按创建时间倒序排列，使用游标分页：将响应中的 next_cursor 作为下一页的 cursor 参数，next_cursor 为空表示没有更多内容。每条点评附带 favorited。
+// @Tags         用户
+// @Produce      json
+// @Param        cursor query string false "上一页返回的 next_cursor"
+// @Param        limit  query int    false "每页数量，最大 100" default(20)
+// @Success      200 {object} services.FeedResult
+// @Failure      400 {object} object{error=string} "无效的游标"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /feed [get]
+func (h *FollowHandler) Feed(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	result, err := h.follows.Feed(userID, c.Query("cursor"), limit)
+	if err != nil {
+		if errors.Is(err, common.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	hideModerationDetailsAll(result.Data)
+	if err := h.favorites.MarkReviews(&userID, result.Data); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
+	"github.com/hdu-dp/backend/internal/services"
+)
+
+// NotificationHandler exposes the signed-in user's notifications.
+type NotificationHandler struct {
+	notifications *services.NotificationService
+}
+
+// NewNotificationHandler constructs a NotificationHandler.
+func NewNotificationHandler(notifications *services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notifications: notifications}
+}
+
+// @Summary      通知列表
+// @Description  获取当前用户的站内通知，按时间倒序排列。通知类型包括点评审核结果、修改审核结果、申诉结果、点评被重新送审或删除、新评论、评论回复、新粉丝以及举报处理结果。
+// @Tags         用户
+// @Produce      json
+// @Param        page      query int  false "页码" default(1)
+// @Param        page_size query int  false "每页数量" default(10)
+// @Param        unread    query bool false "仅返回未读通知"
+// @Success      200 {object} services.NotificationListResult
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /notifications [get]
+func (h *NotificationHandler) List(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+
+	userID := c.MustGet("user_id").(uuid.UUID)
+	result, err := h.notifications.List(userID, services.NotificationFilters{
+		Page:       page,
+		PageSize:   pageSize,
+		UnreadOnly: c.Query("unread") == "true",
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary      未读通知数
+// @Description  获取当前用户的未读通知数量。
+// @Tags         用户
+// @Produce      json
+// @Success      200 {object} object{unread=int} "未读数量"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /notifications/unread-count [get]
+func (h *NotificationHandler) UnreadCount(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+	count, err := h.notifications.UnreadCount(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"unread": count})
+}
+
+// @Summary      标记通知已读
+// @Description  将一条通知标记为已读，重复标记不产生变化。
+// @Tags         用户
+// @Produce      json
+// @Param        id path string true "通知 ID"
+// @Success      200 {object} models.Notification "标记成功"
+// @Failure      400 {object} object{error=string} "无效的通知 ID"
+// @Failure      404 {object} object{error=string} "通知不存在"
+// @Security     ApiKeyAuth
+// @Router       /notifications/{id}/read [put]
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid notification id"})
+		return
+	}
+
+	userID := c.MustGet("user_id").(uuid.UUID)
+	notification, err := h.notifications.MarkRead(userID, id)
+	if err != nil {
+		if errors.Is(err, common.ErrNotificationNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, notification)
+}
+
+// @Summary      全部标记已读
+// @Description  将当前用户的所有未读通知标记为已读，返回本次标记的数量。
+// @Tags         用户
+// @Produce      json
+// @Success      200 {object} object{marked=int} "标记成功"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /notifications/read-all [put]
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+	marked, err := h.notifications.MarkAllRead(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"marked": marked})
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/services"
+)
+
+// PlaceHandler manages place related HTTP endpoints.
+type PlaceHandler struct {
+	places    *services.PlaceService
+	reviews   *services.ReviewService
+	favorites *services.FavoriteService
+}
+
+// NewPlaceHandler constructs a PlaceHandler. Public responses tell signed-in
+// viewers which places and reviews they saved through favorites.
+func NewPlaceHandler(places *services.PlaceService, reviews *services.ReviewService, favorites *services.FavoriteService) *PlaceHandler {
+	return &PlaceHandler{places: places, reviews: reviews, favorites: favorites}
+}
+
+// @Summary      地点列表
+// @Description  获取地点（食堂窗口、餐厅等）列表，支持分页、搜索、校区与分类筛选。携带访问令牌时每个地点附带 favorited，表示当前用户是否已收藏。
+// @Tags         地点
+// @Produce      json
+// @Param        page        query int    false "页码" default(1)
+// @Param        page_size   query int    false "每页数量" default(10)
+// @Param        query       query string false "按名称、地址搜索"
+// @Param        campus_area query string false "校区/区域"
+// @Param        category    query string false "分类"
+// @Param        status      query string false "状态 (pending, active)" enums(pending, active)
+// @Param        sort        query string false "排序字段 (created_at, name, score, rating, reviews)" enums(created_at, name, score, rating, reviews) default(created_at)
+// @Param        order       query string false "排序顺序 (asc, desc)" enums(asc, desc) default(desc)
+// @Success      200 {object} services.PlaceListResult
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Router       /places [get]
+func (h *PlaceHandler) List(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+
+	result, err := h.places.List(services.PlaceListFilters{
+		Page:       page,
+		PageSize:   pageSize,
+		Query:      strings.TrimSpace(c.Query("query")),
+		CampusArea: c.Query("campus_area"),
+		Category:   c.Query("category"),
+		Status:     c.Query("status"),
+		SortBy:     c.DefaultQuery("sort", "created_at"),
+		SortDir:    c.DefaultQuery("order", "desc"),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.favorites.MarkPlaces(viewerID(c), result.Data); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary      地点详情
+// @Description  根据 ID 获取单个地点的信息，包含点评数、均分、评分分布与贝叶斯评分。携带访问令牌时附带 favorited，表示当前用户是否已收藏。
+// @Tags         地点
+// @Produce      json
+// @Param        id path string true "地点 ID"
+// @Success      200 {object} models.Place
+// @Failure      400 {object} object{error=string} "无效的地点 ID"
+// @Failure      404 {object} object{error=string} "地点不存在"
+// @Router       /places/{id} [get]
+func (h *PlaceHandler) Detail(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid place id"})
+		return
+	}
+
+	place, err := h.places.Get(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "place not found"})
+		return
+	}
+	if err := h.favorites.MarkPlace(viewerID(c), place); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, place)
+}
+
+// @Summary      地点下的点评
+// @Description  获取指定地点下已审核通过的点评列表，参数与 favorited 同公开点评列表。
+// @Tags         地点
+// @Produce      json
+// @Param        id        path  string true  "地点 ID"
+// @Param        page      query int    false "页码" default(1)
+// @Param        page_size query int    false "每页数量" default(10)
+// @Param        query     query string false "搜索关键词"
+// @Param        sort      query string false "排序字段 (created_at, rating, score, helpful, taste, value, service, hygiene)" enums(created_at, rating, score, helpful, taste, value, service, hygiene) default(created_at)
+// @Param        min_rating  query number false "总评分下限"
+// @Param        min_taste   query number false "口味评分下限"
+// @Param        min_value   query number false "性价比评分下限"
+// @Param        min_service query number false "服务评分下限"
+// @Param        min_hygiene query number false "卫生评分下限"
+// @Param        order     query string false "排序顺序 (asc, desc)" enums(asc, desc) default(desc)
+// @Success      200 {object} services.ReviewListResult
+// @Failure      400 {object} object{error=string} "无效的地点 ID"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Router       /places/{id}/reviews [get]
+func (h *PlaceHandler) Reviews(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid place id"})
+		return
+	}
+
+	filters := parseListFilters(c)
+	filters.PlaceID = &id
+	result, err := h.reviews.ListPublic(filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	hideModerationDetailsAll(result.Data)
+	if err := h.favorites.MarkReviews(viewerID(c), result.Data); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary      新建地点
+// @Description  已认证用户登记新地点。普通用户创建的地点为待确认状态，管理员创建的地点直接生效。
+// @Tags         地点
+// @Accept       json
+// @Produce      json
+// @Param        body body object{name=string,address=string,campus_area=string,category=string} true "地点信息"
+// @Success      201 {object} models.Place "创建成功"
+// @Failure      400 {object} object{error=string} "请求参数错误"
+// @Security     ApiKeyAuth
+// @Router       /places [post]
+func (h *PlaceHandler) Create(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+	var req struct {
+		Name       string `json:"name"`
+		Address    string `json:"address"`
+		CampusArea string `json:"campus_area"`
+		Category   string `json:"category"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	place, err := h.places.Create(userID, services.PlaceInput{
+		Name:       req.Name,
+		Address:    req.Address,
+		CampusArea: req.CampusArea,
+		Category:   req.Category,
+	}, c.GetString("role") == "admin")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, place)
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
+	"github.com/hdu-dp/backend/internal/models"
+	"github.com/hdu-dp/backend/internal/services"
+)
+
+// ReportHandler lets readers report published reviews.
+type ReportHandler struct {
+	reports *services.ReportService
+	reviews *services.ReviewService
+}
+
+// NewReportHandler constructs a ReportHandler.
+func NewReportHandler(reports *services.ReportService, reviews *services.ReviewService) *ReportHandler {
+	return &ReportHandler{reports: reports, reviews: reviews}
+}
+
+// @Summary      举报点评
+// @Description  举报已发布的点评。reason 取值 spam（广告）、offensive（辱骂或不当内容）、inaccurate（内容失实）、irrelevant（与美食无关）、privacy（泄露隐私）、other（其他，须填写 details）。每位用户对同一点评只能举报一次，不能举报自己的点评；未处理的举报达到阈值后点评重新进入审核队列。
+// @Tags         点评
+// @Accept       json
+// @Produce      json
+// @Param        id   path string true "点评 ID"
+// @Param        body body object{reason=string,details=string} true "举报原因与补充说明"
+// @Success      201 {object} models.ReviewReport "举报成功"
+// @Failure      400 {object} object{error=string} "无效的点评 ID 或请求参数错误"
+// @Failure      404 {object} object{error=string} "点评不存在"
+// @Failure      409 {object} object{error=string} "点评未发布或已举报过"
+// @Security     ApiKeyAuth
+// @Router       /reviews/{id}/reports [post]
+func (h *ReportHandler) Create(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return
+	}
+
+	review, err := h.reviews.Get(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
+		return
+	}
+
+	var req struct {
+		Reason  models.ReportReason `json:"reason"`
+		Details string              `json:"details"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	userID := c.MustGet("user_id").(uuid.UUID)
+	report, err := h.reports.Report(review, userID, services.ReportInput{Reason: req.Reason, Details: req.Details})
+	if err != nil {
+		if errors.Is(err, common.ErrReviewNotReportable) || errors.Is(err, common.ErrAlreadyReported) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
@@ -1,35 +1,46 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
 	"github.com/hdu-dp/backend/internal/models"
 	"github.com/hdu-dp/backend/internal/services"
 	"github.com/hdu-dp/backend/internal/storage"
+	"github.com/hdu-dp/backend/internal/workflow"
 )
 
 // ReviewHandler manages review related HTTP endpoints.
 type ReviewHandler struct {
-	reviews *services.ReviewService
+	reviews   *services.ReviewService
+	favorites *services.FavoriteService
 }
 
-// NewReviewHandler constructs a ReviewHandler.
-func NewReviewHandler(reviews *services.ReviewService) *ReviewHandler {
-	return &ReviewHandler{reviews: reviews}
+// NewReviewHandler constructs a ReviewHandler. Public responses tell
+// signed-in viewers which reviews they saved through favorites.
+func NewReviewHandler(reviews *services.ReviewService, favorites *services.FavoriteService) *ReviewHandler {
+	return &ReviewHandler{reviews: reviews, favorites: favorites}
 }
 
 // @Summary      公开点评列表
-// @Description  获取已审核通过的点评列表，支持分页、搜索和排序。
+// @Description  获取已审核通过的点评列表，支持分页、搜索和排序。携带访问令牌时每条点评附带 favorited，表示当前用户是否已收藏。
 // @Tags         点评
 // @Produce      json
 // @Param        page      query int    false "页码" default(1)
 // @Param        page_size query int    false "每页数量" default(10)
 // @Param        query     query string false "搜索关键词"
-// @Param        sort      query string false "排序字段 (created_at, rating)" enums(created_at, rating) default(created_at)
+// @Param        place_id  query string false "地点 ID"
+// @Param        sort      query string false "排序字段 (created_at, rating, score, helpful, taste, value, service, hygiene)" enums(created_at, rating, score, helpful, taste, value, service, hygiene) default(created_at)
+// @Param        min_rating  query number false "总评分下限"
+// @Param        min_taste   query number false "口味评分下限"
+// @Param        min_value   query number false "性价比评分下限"
+// @Param        min_service query number false "服务评分下限"
+// @Param        min_hygiene query number false "卫生评分下限"
 // @Param        order     query string false "排序顺序 (asc, desc)" enums(asc, desc) default(desc)
 // @Success      200 {object} services.ReviewListResult
 // @Failure      500 {object} object{error=string} "服务器内部错误"
@@ -41,26 +52,36 @@ func (h *ReviewHandler) ListPublic(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	hideModerationDetailsAll(result.Data)
+	if err := h.favorites.MarkReviews(viewerID(c), result.Data); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 	c.JSON(http.StatusOK, result)
 }
 
 // @Summary      提交新点评
-// @Description  已认证用户提交一条新的点评，需要等待管理员审核。
+// @Description  已认证用户提交一条新的点评，需要等待管理员审核。draft 为 true 时保存为草稿，不进入审核，可缺省地址与评分。
 // @Tags         点评
 // @Accept       json
 // @Produce      json
-// @Param        body body object{title=string,address=string,description=string,rating=number} true "点评内容"
+// @Param        body body object{title=string,address=string,description=string,rating=number,ratings=models.DimensionRatings,place_id=string,place_name=string,draft=bool} true "点评内容（rating 缺省时由 ratings 各维度均值得出）"
 // @Success      201 {object} models.Review "创建成功"
 // @Failure      400 {object} object{error=string} "请求参数错误"
+// @Failure      409 {object} object{error=string} "与作者已有点评内容相同"
 // @Security     ApiKeyAuth
 // @Router       /reviews [post]
 func (h *ReviewHandler) Submit(c *gin.Context) {
 	userID := c.MustGet("user_id").(uuid.UUID)
 	var req struct {
-		Title       string  `json:"title"`
-		Address     string  `json:"address"`
-		Description string  `json:"description"`
-		Rating      float32 `json:"rating"`
+		Title       string                  `json:"title"`
+		Address     string                  `json:"address"`
+		Description string                  `json:"description"`
+		Rating      *float32                `json:"rating"`
+		Ratings     models.DimensionRatings `json:"ratings"`
+		PlaceID     *uuid.UUID              `json:"place_id"`
+		PlaceName   string                  `json:"place_name"`
+		Draft       bool                    `json:"draft"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -73,21 +94,33 @@ func (h *ReviewHandler) Submit(c *gin.Context) {
 		Address:     req.Address,
 		Description: req.Description,
 		Rating:      req.Rating,
+		Ratings:     req.Ratings,
+		PlaceID:     req.PlaceID,
+		PlaceName:   req.PlaceName,
+		Draft:       req.Draft,
 	})
 	if err != nil {
+		if errors.Is(err, common.ErrDuplicateReview) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	hideModerationDetails(review)
 	c.JSON(http.StatusCreated, review)
 }
 
 // @Summary      获取点评详情
-// @Description  根据 ID 获取单个点评的详细信息。未审核的点评仅作者和管理员可见。
+// @Description  根据 ID 获取单个点评的详细信息。未审核的点评仅作者和管理员可见。携带访问令牌时附带 favorited，表示当前用户是否已收藏。
 // @Tags         点评
 // @Produce      json
 // @Param        id path string true "点评 ID"
+// @Param        If-None-Match header string false "已缓存的 ETag"
+// @Param        Accept-Language header string false "驳回模板文案的首选语言"
 // @Success      200 {object} models.Review
+// @Success      304 "点评未变化"
 // @Failure      400 {object} object{error=string} "无效的点评 ID"
 // @Failure      403 {object} object{error=string} "无权访问"
 // @Failure      404 {object} object{error=string} "点评不存在"
@@ -105,25 +138,309 @@ func (h *ReviewHandler) Detail(c *gin.Context) {
 		return
 	}
 
-	if review.Status != models.ReviewStatusApproved {
-		roleVal, ok := c.Get("role")
-		role := ""
-		if ok {
-			role, _ = roleVal.(string)
+	roleVal, ok := c.Get("role")
+	role := ""
+	if ok {
+		role, _ = roleVal.(string)
+	}
+	userVal, ok := c.Get("user_id")
+	userID, okID := userVal.(uuid.UUID)
+	privileged := role == "admin" || (ok && okID && review.AuthorID == userID)
+
+	if review.Status != models.ReviewStatusApproved && !privileged {
+		c.JSON(http.StatusForbidden, gin.H{"error": "review not accessible"})
+		return
+	}
+	if !privileged {
+		// Pending edits and appeals stay private.
+		review.PendingEdit = nil
+		review.Appeal = nil
+	}
+	if role != "admin" {
+		hideModerationDetails(review)
+	}
+
+	if err := h.favorites.MarkReview(viewerID(c), review); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.reviews.LocalizeRejection(review, acceptedLanguages(c))
+	c.Header("Vary", "Accept-Language, Authorization")
+	SetReviewETag(c, review)
+	if header := c.GetHeader("If-None-Match"); header != "" && matchesETag(header, ReviewETag(review)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.JSON(http.StatusOK, review)
+}
+
+// @Summary      编辑点评
+// @Description  作者修改自己的点评。待审核或已驳回的点评直接更新并重新进入审核；已通过的点评会生成待审核的修改，审核通过前公开展示原内容。修改地址时按新地址重新关联地点。
+// @Tags         点评
+// @Accept       json
+// @Produce      json
+// @Param        id   path string true "点评 ID"
+// @Param        If-Match header string false "点评的 ETag，版本不符时拒绝修改"
+// @Param        body body object{title=string,address=string,description=string,rating=number,ratings=models.DimensionRatings} true "需要修改的字段（未提供的字段保持不变）"
+// @Success      200  {object} models.Review "修改成功"
+// @Failure      400  {object} object{error=string} "请求参数错误"
+// @Failure      403  {object} object{error=string} "无权操作"
+// @Failure      404  {object} object{error=string} "点评不存在"
+// @Failure      409  {object} object{error=string} "点评当前状态不可编辑、已被并发修改，或与作者其他点评内容相同"
+// @Failure      412  {object} object{error=string} "If-Match 与当前版本不符"
+// @Failure      500  {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /reviews/{id} [put]
+func (h *ReviewHandler) Update(c *gin.Context) {
+	review, ok := h.loadOwnedReview(c)
+	if !ok {
+		return
+	}
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	var req struct {
+		Title       *string                  `json:"title"`
+		Address     *string                  `json:"address"`
+		Description *string                  `json:"description"`
+		Rating      *float32                 `json:"rating"`
+		Ratings     *models.DimensionRatings `json:"ratings"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	if err := h.reviews.Edit(review, userID, services.UpdateReviewInput{
+		Title:       req.Title,
+		Address:     req.Address,
+		Description: req.Description,
+		Rating:      req.Rating,
+		Ratings:     req.Ratings,
+	}); err != nil {
+		switch {
+		case errors.Is(err, common.ErrInvalidReview) || errors.Is(err, common.ErrContentBlocked):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, common.ErrTransitionForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, common.ErrReviewAlreadyProcessed) || errors.Is(err, common.ErrInvalidTransition) ||
+			errors.Is(err, common.ErrVersionConflict) || errors.Is(err, common.ErrDuplicateReview):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
-		if role != "admin" {
-			userVal, ok := c.Get("user_id")
-			userID, okID := userVal.(uuid.UUID)
-			if !ok || !okID || review.AuthorID != userID {
-				c.JSON(http.StatusForbidden, gin.H{"error": "review not accessible"})
-				return
-			}
+		return
+	}
+
+	SetReviewETag(c, review)
+	hideModerationDetails(review)
+	c.JSON(http.StatusOK, review)
+}
+
+// @Summary      提交审核
+// @Description  将草稿或已撤回的点评提交审核。提交前校验标题、地址与评分是否完整，未关联地点时按地址匹配地点。
+// @Tags         点评
+// @Produce      json
+// @Param        id path string true "点评 ID"
+// @Param        If-Match header string false "点评的 ETag，版本不符时拒绝修改"
+// @Success      200 {object} models.Review "提交成功"
+// @Failure      400 {object} object{error=string} "无效的点评 ID 或内容不完整"
+// @Failure      403 {object} object{error=string} "无权操作"
+// @Failure      404 {object} object{error=string} "点评不存在"
+// @Failure      409 {object} object{error=string} "点评不是草稿或已撤回状态、已被并发修改，或与作者其他点评内容相同"
+// @Failure      412 {object} object{error=string} "If-Match 与当前版本不符"
+// @Security     ApiKeyAuth
+// @Router       /reviews/{id}/submit [post]
+func (h *ReviewHandler) SubmitDraft(c *gin.Context) {
+	review, ok := h.loadOwnedReview(c)
+	if !ok {
+		return
+	}
+
+	if err := h.reviews.SubmitForModeration(review); err != nil {
+		if errors.Is(err, common.ErrReviewNotSubmittable) || errors.Is(err, common.ErrVersionConflict) || errors.Is(err, common.ErrDuplicateReview) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	SetReviewETag(c, review)
+	hideModerationDetails(review)
+	c.JSON(http.StatusOK, review)
+}
+
+// @Summary      申诉驳回
+// @Description  作者对被驳回的点评提出申诉，可同时修改点评内容（字段同编辑接口）。申诉后点评进入 appealed 状态，等待管理员处理。每条点评只能申诉一次。
+// @Tags         点评
+// @Accept       json
+// @Produce      json
+// @Param        id   path string true "点评 ID"
+// @Param        If-Match header string false "点评的 ETag，版本不符时拒绝修改"
+// @Param        body body object{message=string,title=string,address=string,description=string,rating=number,ratings=models.DimensionRatings} true "申诉理由及可选的修改内容"
+// @Success      200 {object} models.Review "申诉成功"
+// @Failure      400 {object} object{error=string} "无效的点评 ID 或请求参数错误"
+// @Failure      403 {object} object{error=string} "无权操作"
+// @Failure      404 {object} object{error=string} "点评不存在"
+// @Failure      409 {object} object{error=string} "点评未被驳回或已申诉过、已被并发修改，或修改后与作者其他点评内容相同"
+// @Failure      412 {object} object{error=string} "If-Match 与当前版本不符"
+// @Security     ApiKeyAuth
+// @Router       /reviews/{id}/appeal [post]
+func (h *ReviewHandler) Appeal(c *gin.Context) {
+	review, ok := h.loadOwnedReview(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Message     string                   `json:"message"`
+		Title       *string                  `json:"title"`
+		Address     *string                  `json:"address"`
+		Description *string                  `json:"description"`
+		Rating      *float32                 `json:"rating"`
+		Ratings     *models.DimensionRatings `json:"ratings"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	input := services.AppealInput{Message: req.Message}
+	if req.Title != nil || req.Address != nil || req.Description != nil || req.Rating != nil || req.Ratings != nil {
+		input.Edit = &services.UpdateReviewInput{
+			Title:       req.Title,
+			Address:     req.Address,
+			Description: req.Description,
+			Rating:      req.Rating,
+			Ratings:     req.Ratings,
+		}
+	}
+
+	if err := h.reviews.Appeal(review, input); err != nil {
+		if errors.Is(err, common.ErrReviewNotAppealable) || errors.Is(err, common.ErrAppealExists) || errors.Is(err, common.ErrVersionConflict) || errors.Is(err, common.ErrDuplicateReview) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
 		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
+	SetReviewETag(c, review)
+	hideModerationDetails(review)
 	c.JSON(http.StatusOK, review)
 }
 
+// @Summary      撤回点评
+// @Description  作者将待审核的点评撤出审核队列（状态变为 withdrawn，内容保留，再次编辑或提交后重新进入审核）；若为已通过点评的待审核修改，则撤销该修改。
+// @Tags         点评
+// @Produce      json
+// @Param        id path string true "点评 ID"
+// @Param        If-Match header string false "点评的 ETag，版本不符时拒绝修改"
+// @Success      200 {object} models.Review "撤回成功"
+// @Failure      400 {object} object{error=string} "无效的点评 ID"
+// @Failure      403 {object} object{error=string} "无权操作"
+// @Failure      404 {object} object{error=string} "点评不存在"
+// @Failure      409 {object} object{error=string} "点评不在审核中，或已被并发修改"
+// @Failure      412 {object} object{error=string} "If-Match 与当前版本不符"
+// @Security     ApiKeyAuth
+// @Router       /reviews/{id}/withdraw [post]
+func (h *ReviewHandler) Withdraw(c *gin.Context) {
+	review, ok := h.loadOwnedReview(c)
+	if !ok {
+		return
+	}
+
+	if err := h.reviews.Withdraw(review); err != nil {
+		if errors.Is(err, common.ErrReviewNotWithdrawable) || errors.Is(err, common.ErrVersionConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	SetReviewETag(c, review)
+	hideModerationDetails(review)
+	c.JSON(http.StatusOK, review)
+}
+
+// @Summary      删除自己的点评
+// @Description  作者删除自己的点评。点评移入回收站，管理员可恢复，保留期满后连同图片永久删除。
+// @Tags         点评
+// @Produce      json
+// @Param        id path string true "点评 ID"
+// @Param        If-Match header string false "点评的 ETag，版本不符时拒绝修改"
+// @Success      204 "删除成功"
+// @Failure      400 {object} object{error=string} "无效的点评 ID"
+// @Failure      403 {object} object{error=string} "无权操作"
+// @Failure      404 {object} object{error=string} "点评不存在"
+// @Failure      409 {object} object{error=string} "点评已被并发修改"
+// @Failure      412 {object} object{error=string} "If-Match 与当前版本不符"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /reviews/{id} [delete]
+func (h *ReviewHandler) Delete(c *gin.Context) {
+	review, ok := h.loadOwnedReview(c)
+	if !ok {
+		return
+	}
+
+	userID := c.MustGet("user_id").(uuid.UUID)
+	if err := h.reviews.DeleteReview(review, workflow.ActorAuthor, userID, ""); err != nil {
+		if errors.Is(err, common.ErrVersionConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      点评修改历史
+// @Description  获取点评的全部修改记录（修改人、时间、字段及新旧值），仅作者与管理员可见。
+// @Tags         点评
+// @Produce      json
+// @Param        id path string true "点评 ID"
+// @Success      200 {object} object{data=[]models.ReviewRevision}
+// @Failure      400 {object} object{error=string} "无效的点评 ID"
+// @Failure      403 {object} object{error=string} "无权访问"
+// @Failure      404 {object} object{error=string} "点评不存在"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /reviews/{id}/revisions [get]
+func (h *ReviewHandler) Revisions(c *gin.Context) {
+	reviewID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return
+	}
+
+	review, err := h.reviews.Get(reviewID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
+		return
+	}
+
+	userID := c.MustGet("user_id").(uuid.UUID)
+	if c.GetString("role") != "admin" {
+		if err := services.ValidateOwnership(review, userID); err != nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": "review not accessible"})
+			return
+		}
+	}
+
+	revisions, err := h.reviews.ListRevisions(review.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": revisions})
+}
+
 // @Summary      我的点评列表
 // @Description  获取当前认证用户提交的所有点评列表，支持分页、搜索和排序。
 // @Tags         点评
@@ -131,8 +448,14 @@ func (h *ReviewHandler) Detail(c *gin.Context) {
 // @Param        page      query int    false "页码" default(1)
 // @Param        page_size query int    false "每页数量" default(10)
 // @Param        query     query string false "搜索关键词"
-// @Param        sort      query string false "排序字段 (created_at, rating)" enums(created_at, rating) default(created_at)
+// @Param        sort      query string false "排序字段 (created_at, rating, score, helpful, taste, value, service, hygiene)" enums(created_at, rating, score, helpful, taste, value, service, hygiene) default(created_at)
+// @Param        min_rating  query number false "总评分下限"
+// @Param        min_taste   query number false "口味评分下限"
+// @Param        min_value   query number false "性价比评分下限"
+// @Param        min_service query number false "服务评分下限"
+// @Param        min_hygiene query number false "卫生评分下限"
 // @Param        order     query string false "排序顺序 (asc, desc)" enums(asc, desc) default(desc)
+// @Param        Accept-Language header string false "驳回模板文案的首选语言"
 // @Success      200 {object} services.ReviewListResult
 // @Failure      500 {object} object{error=string} "服务器内部错误"
 // @Security     ApiKeyAuth
@@ -145,6 +468,8 @@ func (h *ReviewHandler) MyReviews(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	h.reviews.LocalizeRejections(result.Data, acceptedLanguages(c))
+	hideModerationDetailsAll(result.Data)
 	c.JSON(http.StatusOK, result)
 }
 
@@ -209,6 +534,56 @@ func (h *ReviewHandler) UploadImage(c *gin.Context) {
 	c.JSON(http.StatusCreated, image)
 }
 
+// loadOwnedReview resolves the :id review for a mutation, checking the
+// current user owns it and any If-Match precondition, and writes the error
+// response otherwise.
+func (h *ReviewHandler) loadOwnedReview(c *gin.Context) (*models.Review, bool) {
+	reviewID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return nil, false
+	}
+
+	review, err := h.reviews.Get(reviewID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
+		return nil, false
+	}
+
+	userID := c.MustGet("user_id").(uuid.UUID)
+	if err := services.ValidateOwnership(review, userID); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not owner"})
+		return nil, false
+	}
+	if !CheckIfMatch(c, review) {
+		return nil, false
+	}
+
+	return review, true
+}
+
+// hideModerationDetails strips what only administrators may see: the
+// pre-moderation assessment, flagged words, matches against other reviews
+// and the moderation claim.
+func hideModerationDetails(review *models.Review) {
+	review.Assessment = nil
+	review.ModerationFlags = nil
+	review.Duplicates = nil
+	review.ClaimedByID = nil
+	review.ClaimExpiresAt = nil
+	if review.PendingEdit != nil {
+		review.PendingEdit.ModerationFlags = nil
+		review.PendingEdit.Duplicates = nil
+	}
+}
+
+// hideModerationDetailsAll applies hideModerationDetails to a listing.
+func hideModerationDetailsAll(reviews []models.Review) {
+	for i := range reviews {
+		hideModerationDetails(&reviews[i])
+	}
+}
+
 func parseListFilters(c *gin.Context) services.ListFilters {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
@@ -216,11 +591,27 @@ func parseListFilters(c *gin.Context) services.ListFilters {
 	sortBy := c.DefaultQuery("sort", "created_at")
 	sortDir := c.DefaultQuery("order", "desc")
 
-	return services.ListFilters{
+	filters := services.ListFilters{
 		Page:     page,
 		PageSize: pageSize,
 		Query:    query,
 		SortBy:   sortBy,
 		SortDir:  sortDir,
 	}
+	if placeID, err := uuid.Parse(c.Query("place_id")); err == nil {
+		filters.PlaceID = &placeID
+	}
+	for _, dim := range models.AllRatingDimensions {
+		raw := c.Query("min_" + string(dim))
+		if raw == "" {
+			continue
+		}
+		if min, err := strconv.ParseFloat(raw, 32); err == nil {
+			if filters.MinRatings == nil {
+				filters.MinRatings = make(map[models.RatingDimension]float32)
+			}
+			filters.MinRatings[dim] = float32(min)
+		}
+	}
+	return filters
 }
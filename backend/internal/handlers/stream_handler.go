@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/realtime"
+	"github.com/hdu-dp/backend/internal/repository"
+)
+
+// streamRetry is the reconnection delay suggested to clients, in
+// milliseconds.
+const streamRetry = 3000
+
+// StreamHandler pushes events to signed-in clients over Server-Sent Events.
+type StreamHandler struct {
+	hub       *realtime.Hub
+	users     *repository.UserRepository
+	heartbeat time.Duration
+}
+
+// NewStreamHandler constructs a StreamHandler. Idle streams receive a
+// keep-alive comment every heartbeat so that proxies keep them open. The
+// subscriber's role is read from users rather than the token and checked
+// again every heartbeat, so a stream does not outlive a role change.
+func NewStreamHandler(hub *realtime.Hub, users *repository.UserRepository, heartbeat time.Duration) *StreamHandler {
+	return &StreamHandler{hub: hub, users: users, heartbeat: heartbeat}
+}
+
+// @Summary      实时事件流
+// @Description  以 Server-Sent Events 推送事件：点评作者收到 review_status（点评状态变化）与 notification（新通知），管理员另收到 review_queued / review_dequeued（点评或修改进入、离开审核队列）。用户角色变化后，服务端在下一次保活时关闭事件流，客户端重连后按新角色订阅。断线重连时携带 Last-Event-ID 请求头可补发期间错过的事件；无法补发时先推送 resync 事件，客户端应重新拉取数据。
+// @Tags         用户
+// @Produce      text/event-stream
+// @Param        Last-Event-ID header string false "最后收到的事件 ID"
+// @Success      200 {string} string "事件流"
+// @Failure      401 {object} object{error=string} "未认证"
+// @Failure      404 {object} object{error=string} "用户不存在"
+// @Security     ApiKeyAuth
+// @Router       /events [get]
+func (h *StreamHandler) Stream(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+	user, err := h.users.FindByID(userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		return
+	}
+	admin := user.Role == "admin"
+	sub := h.hub.Subscribe(userID, admin, c.GetHeader("Last-Event-ID"))
+	defer h.hub.Unsubscribe(sub)
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", streamRetry)
+	switch {
+	case !sub.Resumed:
+		writeEvent(c, sub.Head, "resync", []byte("{}"))
+	case c.GetHeader("Last-Event-ID") == "":
+		writeEvent(c, sub.Head, "ready", []byte("{}"))
+	}
+	for _, m := range sub.Missed {
+		writeEvent(c, m.ID, m.Event, m.Data)
+	}
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-ticker.C:
+			// The client reconnects and subscribes with its current role.
+			if user, err := h.users.FindByID(userID); err != nil || (user.Role == "admin") != admin {
+				return
+			}
+			fmt.Fprint(c.Writer, ": ping\n\n")
+		case m, ok := <-sub.C():
+			if !ok {
+				// The client fell behind; it resumes from the backlog when
+				// it reconnects.
+				return
+			}
+			writeEvent(c, m.ID, m.Event, m.Data)
+		}
+		c.Writer.Flush()
+	}
+}
+
+func writeEvent(c *gin.Context, id, event string, data []byte) {
+	fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", id, event, data)
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
+	"github.com/hdu-dp/backend/internal/models"
+	"github.com/hdu-dp/backend/internal/services"
+)
+
+// VoteHandler lets readers mark published reviews as helpful or not.
+type VoteHandler struct {
+	votes   *services.VoteService
+	reviews *services.ReviewService
+}
+
+// NewVoteHandler constructs a VoteHandler.
+func NewVoteHandler(votes *services.VoteService, reviews *services.ReviewService) *VoteHandler {
+	return &VoteHandler{votes: votes, reviews: reviews}
+}
+
+// @Summary      评价点评是否有用
+// @Description  对已发布的点评投“有用”（helpful=true）或“没用”（helpful=false）票。每位用户对同一点评只保留一票，重复投相同的票不产生变化，改投会替换原来的票；不能给自己的点评投票。返回当前投票与点评最新的票数和有用度得分。
+// @Tags         点评
+// @Accept       json
+// @Produce      json
+// @Param        id   path string true "点评 ID"
+// @Param        body body object{helpful=bool} true "是否有用"
+// @Success      200 {object} services.VoteResult "投票成功"
+// @Failure      400 {object} object{error=string} "无效的点评 ID、请求参数错误或给自己的点评投票"
+// @Failure      404 {object} object{error=string} "点评不存在"
+// @Failure      409 {object} object{error=string} "点评未发布"
+// @Security     ApiKeyAuth
+// @Router       /reviews/{id}/vote [put]
+func (h *VoteHandler) Vote(c *gin.Context) {
+	review, ok := h.loadReview(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Helpful *bool `json:"helpful"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || req.Helpful == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	userID := c.MustGet("user_id").(uuid.UUID)
+	result, err := h.votes.Vote(review, userID, *req.Helpful)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrReviewNotVotable):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, common.ErrOwnReviewVote):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary      撤回投票
+// @Description  撤回自己对点评的投票，未投过票时不产生变化。返回点评最新的票数和有用度得分。
+// @Tags         点评
+// @Produce      json
+// @Param        id path string true "点评 ID"
+// @Success      200 {object} services.VoteResult "撤回成功"
+// @Failure      400 {object} object{error=string} "无效的点评 ID"
+// @Failure      404 {object} object{error=string} "点评不存在"
+// @Failure      500 {object} object{error=string} "服务器内部错误"
+// @Security     ApiKeyAuth
+// @Router       /reviews/{id}/vote [delete]
+func (h *VoteHandler) Unvote(c *gin.Context) {
+	review, ok := h.loadReview(c)
+	if !ok {
+		return
+	}
+
+	userID := c.MustGet("user_id").(uuid.UUID)
+	result, err := h.votes.Unvote(review, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *VoteHandler) loadReview(c *gin.Context) (*models.Review, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return nil, false
+	}
+
+	review, err := h.reviews.Get(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
+		return nil, false
+	}
+	return review, true
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CommentStatus enumerates comment moderation states.
+type CommentStatus string
+
+const (
+	CommentStatusPending  CommentStatus = "pending"
+	CommentStatusApproved CommentStatus = "approved"
+	CommentStatusRejected CommentStatus = "rejected"
+)
+
+// Comment is a reader's remark on a published review. Top-level comments may
+// have replies; replies cannot be replied to in turn.
+type Comment struct {
+	ID       uuid.UUID     `gorm:"type:char(36);primaryKey" json:"id"`
+	ReviewID uuid.UUID     `gorm:"type:char(36);index;not null" json:"review_id"`
+	ParentID *uuid.UUID    `gorm:"type:char(36);index" json:"parent_id"`
+	AuthorID uuid.UUID     `gorm:"type:char(36);index;not null" json:"author_id"`
+	Author   User          `gorm:"foreignKey:AuthorID" json:"author"`
+	Body     string        `gorm:"type:text;not null" json:"body"`
+	Status   CommentStatus `gorm:"size:20;not null;default:pending;index" json:"status"`
+	// RejectionReason explains a rejection to the author.
+	RejectionReason string `gorm:"type:text" json:"rejection_reason,omitempty"`
+	// ModerationFlags lists the flagged sensitive words found in the body, as
+	// "category:word"; decisions clear it.
+	ModerationFlags []string `gorm:"serializer:json" json:"moderation_flags,omitempty"`
+	// Replies holds the approved replies to a top-level comment in listings.
+	Replies   []Comment      `gorm:"foreignKey:ParentID" json:"replies,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// BeforeCreate assigns a UUID if empty.
+func (c *Comment) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FavoriteType enumerates what a favorite points at.
+type FavoriteType string
+
+const (
+	FavoriteTypeReview FavoriteType = "review"
+	FavoriteTypePlace  FavoriteType = "place"
+)
+
+// Favorite is a review or place a user has saved for later. Exactly one of
+// ReviewID and PlaceID is set, according to Type.
+type Favorite struct {
+	ID        uuid.UUID    `gorm:"type:char(36);primaryKey" json:"id"`
+	UserID    uuid.UUID    `gorm:"type:char(36);not null;uniqueIndex:idx_favorite_review;uniqueIndex:idx_favorite_place" json:"user_id"`
+	Type      FavoriteType `gorm:"size:20;not null;index" json:"type"`
+	ReviewID  *uuid.UUID   `gorm:"type:char(36);uniqueIndex:idx_favorite_review" json:"review_id,omitempty"`
+	Review    *Review      `gorm:"foreignKey:ReviewID" json:"review,omitempty"`
+	PlaceID   *uuid.UUID   `gorm:"type:char(36);uniqueIndex:idx_favorite_place" json:"place_id,omitempty"`
+	Place     *Place       `gorm:"foreignKey:PlaceID" json:"place,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// BeforeCreate assigns a UUID if empty.
+func (f *Favorite) BeforeCreate(tx *gorm.DB) error {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Follow records that one user follows another to see their reviews in the
+// feed.
+type Follow struct {
+	ID         uuid.UUID `gorm:"type:char(36);primaryKey" json:"id"`
+	FollowerID uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_follow_pair" json:"follower_id"`
+	Follower   *User     `gorm:"foreignKey:FollowerID" json:"follower,omitempty"`
+	FolloweeID uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_follow_pair;index" json:"followee_id"`
+	Followee   *User     `gorm:"foreignKey:FolloweeID" json:"followee,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// BeforeCreate assigns a UUID if empty.
+func (f *Follow) BeforeCreate(tx *gorm.DB) error {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ModerationAction is an audit record of one moderation decision taken on a
+// review by an administrator or by the system.
+type ModerationAction struct {
+	ID uuid.UUID `gorm:"type:char(36);primaryKey" json:"id"`
+	// ActorID is nil for system actions.
+	ActorID   *uuid.UUID   `gorm:"type:char(36);index" json:"actor_id"`
+	Actor     *User        `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+	ActorRole string       `gorm:"size:20;not null" json:"actor_role"`
+	ReviewID  uuid.UUID    `gorm:"type:char(36);index;not null" json:"review_id"`
+	AuthorID  uuid.UUID    `gorm:"type:char(36);index;not null" json:"author_id"`
+	Action    string       `gorm:"size:40;index;not null" json:"action"`
+	Reason    string       `gorm:"type:text" json:"reason"`
+	FromState ReviewStatus `gorm:"size:20" json:"from_status"`
+	ToState   ReviewStatus `gorm:"size:20" json:"to_status"`
+	CreatedAt time.Time    `gorm:"index" json:"created_at"`
+}
+
+// BeforeCreate assigns a UUID if empty.
+func (a *ModerationAction) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
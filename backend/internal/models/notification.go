@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// NotificationType enumerates the events users are notified about.
+type NotificationType string
+
+const (
+	NotificationReviewApproved NotificationType = "review_approved"
+	NotificationReviewRejected NotificationType = "review_rejected"
+	NotificationEditApproved   NotificationType = "edit_approved"
+	NotificationEditRejected   NotificationType = "edit_rejected"
+	NotificationAppealAccepted NotificationType = "appeal_accepted"
+	NotificationAppealDenied   NotificationType = "appeal_denied"
+	// NotificationReviewRequeued tells the author a published review went
+	// back to moderation, for instance after being reported.
+	NotificationReviewRequeued NotificationType = "review_requeued"
+	NotificationReviewDeleted  NotificationType = "review_deleted"
+	// NotificationNewComment tells the author of a review about a published
+	// comment on it; NotificationCommentReply tells the author of a comment
+	// about a published reply to it.
+	NotificationNewComment      NotificationType = "new_comment"
+	NotificationCommentReply    NotificationType = "comment_reply"
+	NotificationNewFollower     NotificationType = "new_follower"
+	NotificationReportResolved  NotificationType = "report_resolved"
+	NotificationReportDismissed NotificationType = "report_dismissed"
+)
+
+// Notification tells a user about something that happened to their content
+// or account. The IDs point at what it is about; Message carries any text
+// the event came with, such as a rejection reason.
+type Notification struct {
+	ID        uuid.UUID        `gorm:"type:char(36);primaryKey" json:"id"`
+	UserID    uuid.UUID        `gorm:"type:char(36);not null;index:idx_notification_user_created" json:"user_id"`
+	Type      NotificationType `gorm:"size:40;not null" json:"type"`
+	ActorID   *uuid.UUID       `gorm:"type:char(36)" json:"actor_id,omitempty"`
+	ReviewID  *uuid.UUID       `gorm:"type:char(36);index" json:"review_id,omitempty"`
+	CommentID *uuid.UUID       `gorm:"type:char(36)" json:"comment_id,omitempty"`
+	ReportID  *uuid.UUID       `gorm:"type:char(36)" json:"report_id,omitempty"`
+	Message   string           `gorm:"type:text" json:"message,omitempty"`
+	// ReadAt is when the user marked the notification read; nil while unread.
+	ReadAt    *time.Time `gorm:"index" json:"read_at"`
+	CreatedAt time.Time  `gorm:"index:idx_notification_user_created,priority:2" json:"created_at"`
+}
+
+// BeforeCreate assigns a UUID if empty.
+func (n *Notification) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/textfilter"
+	"gorm.io/gorm"
+)
+
+// Place represents a canteen window, restaurant or stall that reviews refer to.
+type Place struct {
+	ID          uuid.UUID        `gorm:"type:char(36);primaryKey" json:"id"`
+	Name        string           `gorm:"size:120;not null;index" json:"name"`
+	Address     string           `gorm:"size:255;not null" json:"address"`
+	CampusArea  string           `gorm:"size:60;index" json:"campus_area"`
+	Category    string           `gorm:"size:60;index" json:"category"`
+	Status      PlaceStatus      `gorm:"size:20;default:pending;index" json:"status"`
+	CreatedByID *uuid.UUID       `gorm:"type:char(36)" json:"created_by_id,omitempty"`
+	Stats       PlaceRatingStats `gorm:"embedded" json:"stats"`
+	// NameKey and AddressKey are Name and Address folded for address
+	// matching; see SyncMatchKeys.
+	NameKey    string    `gorm:"size:120;index" json:"-"`
+	AddressKey string    `gorm:"size:255;index" json:"-"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	// Favorited reports whether the signed-in viewer saved the place; it is
+	// not stored and is omitted for anonymous viewers.
+	Favorited *bool `gorm:"-" json:"favorited,omitempty"`
+}
+
+// BeforeCreate assigns a UUID if empty.
+func (p *Place) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// SyncMatchKeys recomputes NameKey and AddressKey with textfilter.Canonical,
+// which ignores case, character width, spacing and punctuation.
+func (p *Place) SyncMatchKeys() {
+	p.NameKey = textfilter.Canonical(p.Name)
+	p.AddressKey = textfilter.Canonical(p.Address)
+}
+
+// AfterFind rebuilds derived rating fields after loading.
+func (p *Place) AfterFind(tx *gorm.DB) error {
+	p.Stats.syncHistogram()
+	return nil
+}
+
+// PlaceStatus enumerates place curation states. Places created implicitly by
+// review submissions start as pending until an administrator confirms them.
+type PlaceStatus string
+
+const (
+	PlaceStatusPending PlaceStatus = "pending"
+	PlaceStatusActive  PlaceStatus = "active"
+)
+
+// BayesianPrior parameterises the weighted place score: a place is treated as
+// if it had Weight extra reviews rated Mean, so a single 5-star review cannot
+// outrank a place with many consistently good ones.
+type BayesianPrior struct {
+	Mean   float64
+	Weight float64
+}
+
+// PlaceRatingStats holds aggregates over the approved reviews of a place. The
+// columns are maintained incrementally as reviews enter or leave the approved
+// state.
+type PlaceRatingStats struct {
+	ReviewCount   int64    `gorm:"not null;default:0" json:"review_count"`
+	RatingSum     float64  `gorm:"not null;default:0" json:"-"`
+	AverageRating float64  `gorm:"not null;default:0" json:"average_rating"`
+	Score         float64  `gorm:"not null;default:0;index" json:"score"`
+	Rating1Count  int64    `gorm:"not null;default:0" json:"-"`
+	Rating2Count  int64    `gorm:"not null;default:0" json:"-"`
+	Rating3Count  int64    `gorm:"not null;default:0" json:"-"`
+	Rating4Count  int64    `gorm:"not null;default:0" json:"-"`
+	Rating5Count  int64    `gorm:"not null;default:0" json:"-"`
+	Histogram     [5]int64 `gorm:"-" json:"rating_histogram"`
+}
+
+// Reset replaces the aggregates with ones computed from the given ratings.
+func (s *PlaceRatingStats) Reset(ratings []float32, prior BayesianPrior) {
+	*s = PlaceRatingStats{}
+	for _, rating := range ratings {
+		s.ReviewCount++
+		s.RatingSum += float64(rating)
+		*s.bucket(rating)++
+	}
+	s.recompute(prior)
+}
+
+func (s *PlaceRatingStats) recompute(prior BayesianPrior) {
+	if s.ReviewCount <= 0 {
+		s.ReviewCount, s.RatingSum, s.AverageRating, s.Score = 0, 0, 0, 0
+		s.syncHistogram()
+		return
+	}
+	n := float64(s.ReviewCount)
+	s.AverageRating = round2(s.RatingSum / n)
+	s.Score = round2((prior.Weight*prior.Mean + s.RatingSum) / (prior.Weight + n))
+	s.syncHistogram()
+}
+
+// bucket maps a 0-5 rating to its star column, rounding half stars up.
+func (s *PlaceRatingStats) bucket(rating float32) *int64 {
+	star := int(math.Round(float64(rating)))
+	switch {
+	case star <= 1:
+		return &s.Rating1Count
+	case star == 2:
+		return &s.Rating2Count
+	case star == 3:
+		return &s.Rating3Count
+	case star == 4:
+		return &s.Rating4Count
+	default:
+		return &s.Rating5Count
+	}
+}
+
+// RatingBucketColumn names the star column a 0-5 rating is counted in,
+// rounding half stars up like the in-memory aggregates do.
+func RatingBucketColumn(rating float32) string {
+	star := int(math.Round(float64(rating)))
+	return fmt.Sprintf("rating%d_count", min(max(star, 1), 5))
+}
+
+func (s *PlaceRatingStats) syncHistogram() {
+	s.Histogram = [5]int64{s.Rating1Count, s.Rating2Count, s.Rating3Count, s.Rating4Count, s.Rating5Count}
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
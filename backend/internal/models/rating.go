@@ -0,0 +1,70 @@
+package models
+
+import "math"
+
+// RatingDimension names a sortable/filterable aspect of a review's rating.
+type RatingDimension string
+
+const (
+	RatingOverall RatingDimension = "rating"
+	RatingTaste   RatingDimension = "taste"
+	RatingValue   RatingDimension = "value"
+	RatingService RatingDimension = "service"
+	RatingHygiene RatingDimension = "hygiene"
+)
+
+// AllRatingDimensions lists the overall rating followed by each aspect.
+var AllRatingDimensions = []RatingDimension{RatingOverall, RatingTaste, RatingValue, RatingService, RatingHygiene}
+
+// Column returns the reviews table column backing the dimension, or "" for
+// unknown dimensions.
+func (d RatingDimension) Column() string {
+	switch d {
+	case RatingOverall:
+		return "rating"
+	case RatingTaste, RatingValue, RatingService, RatingHygiene:
+		return "rating_" + string(d)
+	default:
+		return ""
+	}
+}
+
+// DimensionRatings holds the optional per-aspect scores of a review, each in
+// the same 0-5 range as the overall rating.
+type DimensionRatings struct {
+	Taste   *float32 `gorm:"type:decimal(2,1)" json:"taste"`
+	Value   *float32 `gorm:"type:decimal(2,1)" json:"value"`
+	Service *float32 `gorm:"type:decimal(2,1)" json:"service"`
+	Hygiene *float32 `gorm:"type:decimal(2,1)" json:"hygiene"`
+}
+
+// Values returns the supplied scores keyed by dimension.
+func (d DimensionRatings) Values() map[RatingDimension]float32 {
+	values := make(map[RatingDimension]float32, 4)
+	for dim, v := range map[RatingDimension]*float32{
+		RatingTaste:   d.Taste,
+		RatingValue:   d.Value,
+		RatingService: d.Service,
+		RatingHygiene: d.Hygiene,
+	} {
+		if v != nil {
+			values[dim] = *v
+		}
+	}
+	return values
+}
+
+// Average returns the mean of the supplied scores rounded to one decimal, and
+// false when no dimension was rated.
+func (d DimensionRatings) Average() (float32, bool) {
+	values := d.Values()
+	if len(values) == 0 {
+		return 0, false
+	}
+	var sum float32
+	for _, v := range values {
+		sum += v
+	}
+	avg := float64(sum) / float64(len(values))
+	return float32(math.Round(avg*10) / 10), true
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RejectionReason is a reusable rejection template. Administrators reject a
+// review by Code and authors are shown Message, or its translation for their
+// language.
+type RejectionReason struct {
+	ID   uuid.UUID `gorm:"type:char(36);primaryKey" json:"id"`
+	Code string    `gorm:"size:64;uniqueIndex;not null" json:"code"`
+	// Title labels the template for administrators.
+	Title   string `gorm:"size:120;not null" json:"title"`
+	Message string `gorm:"type:text;not null" json:"message"`
+	// Translations maps lower-case language tags such as "en" or "zh-tw" to
+	// localized messages.
+	Translations map[string]string `gorm:"serializer:json" json:"translations"`
+	// Active templates can be selected when rejecting; inactive ones are kept
+	// so existing rejections still render.
+	Active    bool      `gorm:"not null" json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BeforeCreate assigns a UUID if empty.
+func (r *RejectionReason) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// Localize returns the message for the first supported language in langs,
+// matching exact tags before their primary subtag, or the default message.
+func (r *RejectionReason) Localize(langs []string) string {
+	for _, lang := range langs {
+		if msg, ok := r.Translations[lang]; ok && msg != "" {
+			return msg
+		}
+		if i := strings.IndexByte(lang, '-'); i > 0 {
+			if msg, ok := r.Translations[lang[:i]]; ok && msg != "" {
+				return msg
+			}
+		}
+	}
+	return r.Message
+}
@@ -9,18 +9,53 @@ import (
 
 // Review represents a food review submitted by a user.
 type Review struct {
-	ID              uuid.UUID     `gorm:"type:char(36);primaryKey" json:"id"`
-	Title           string        `gorm:"size:120;not null" json:"title"`
-	Address         string        `gorm:"size:255;not null" json:"address"`
-	Description     string        `gorm:"type:text" json:"description"`
-	Rating          float32       `gorm:"type:decimal(2,1);not null" json:"rating"`
-	Status          ReviewStatus  `gorm:"size:20;default:pending" json:"status"`
-	RejectionReason string        `gorm:"type:text" json:"rejection_reason"`
-	AuthorID        uuid.UUID     `gorm:"type:char(36);not null" json:"author_id"`
+	ID          uuid.UUID        `gorm:"type:char(36);primaryKey" json:"id"`
+	Title       string           `gorm:"size:120;not null" json:"title"`
+	Address     string           `gorm:"size:255;not null" json:"address"`
+	PlaceID     *uuid.UUID       `gorm:"type:char(36);index" json:"place_id"`
+	Place       *Place           `gorm:"foreignKey:PlaceID" json:"place,omitempty"`
+	Description string           `gorm:"type:text" json:"description"`
+	Rating      float32          `gorm:"type:decimal(2,1);not null" json:"rating"`
+	Ratings     DimensionRatings `gorm:"embedded;embeddedPrefix:rating_" json:"ratings"`
+	Status      ReviewStatus     `gorm:"size:20;default:pending" json:"status"`
+	// Version increases with every change and guards against lost updates.
+	Version         int64  `gorm:"not null;default:1" json:"version"`
+	RejectionReason string `gorm:"type:text" json:"rejection_reason"`
+	// RejectionCode is the template the review was rejected with, if any.
+	RejectionCode string `gorm:"size:64" json:"rejection_code,omitempty"`
+	// RejectionMessage is the template's message localized for the viewer;
+	// it is not stored.
+	RejectionMessage string `gorm:"-" json:"rejection_message,omitempty"`
+	// ModerationFlags lists the flagged sensitive words found in the content
+	// awaiting moderation, as "category:word"; decisions clear it.
+	ModerationFlags []string      `gorm:"serializer:json" json:"moderation_flags,omitempty"`
+	AuthorID        uuid.UUID     `gorm:"type:char(36);not null;index:idx_reviews_author_created" json:"author_id"`
 	Author          User          `gorm:"foreignKey:AuthorID" json:"author"`
 	Images          []ReviewImage `gorm:"foreignKey:ReviewID" json:"images"`
-	CreatedAt       time.Time     `json:"created_at"`
-	UpdatedAt       time.Time     `json:"updated_at"`
+	PendingEdit     *ReviewEdit   `gorm:"foreignKey:ReviewID" json:"pending_edit,omitempty"`
+	Appeal          *ReviewAppeal `gorm:"foreignKey:ReviewID" json:"appeal,omitempty"`
+	// Duplicates lists existing reviews with the same or nearly the same text
+	// as the content awaiting moderation; decisions clear it.
+	Duplicates []DuplicateMatch `gorm:"serializer:json" json:"duplicates,omitempty"`
+	// Assessment is the pre-moderation verdict, shown to administrators only.
+	Assessment *ReviewAssessment `gorm:"foreignKey:ReviewID" json:"assessment,omitempty"`
+	// ReviewVoteStats tallies readers' helpfulness votes; the columns are
+	// written only when a vote changes.
+	ReviewVoteStats `gorm:"embedded"`
+	// Favorited reports whether the signed-in viewer saved the review; it is
+	// not stored and is omitted for anonymous viewers.
+	Favorited *bool `gorm:"-" json:"favorited,omitempty"`
+	// ClaimedByID is the administrator holding a lease on the review while
+	// moderating it; the lease lapses at ClaimExpiresAt.
+	ClaimedByID    *uuid.UUID `gorm:"type:char(36);index" json:"claimed_by_id,omitempty"`
+	ClaimExpiresAt *time.Time `json:"claim_expires_at,omitempty"`
+	CreatedAt      time.Time  `gorm:"index:idx_reviews_author_created,priority:2" json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	// DeletedAt soft-deletes the review; trashed reviews are hidden from all
+	// queries until restored or purged after the retention period.
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	DeletedByID    *uuid.UUID     `gorm:"type:char(36)" json:"deleted_by_id,omitempty"`
+	DeletionReason string         `gorm:"type:text" json:"deletion_reason,omitempty"`
 }
 
 // BeforeCreate assigns a UUID if empty.
@@ -31,11 +66,28 @@ func (r *Review) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// ClaimedByOther reports whether another administrator holds an unexpired
+// claim on the review.
+func (r *Review) ClaimedByOther(adminID uuid.UUID, now time.Time) bool {
+	return r.ClaimedByID != nil && *r.ClaimedByID != adminID &&
+		r.ClaimExpiresAt != nil && r.ClaimExpiresAt.After(now)
+}
+
 // ReviewStatus enumerates review workflow states.
 type ReviewStatus string
 
 const (
+	// ReviewStatusDraft marks a review the author is still composing; it is
+	// private and may be incomplete until submitted for moderation.
+	ReviewStatusDraft    ReviewStatus = "draft"
 	ReviewStatusPending  ReviewStatus = "pending"
 	ReviewStatusApproved ReviewStatus = "approved"
 	ReviewStatusRejected ReviewStatus = "rejected"
+	// ReviewStatusAppealed marks a rejected review whose author appealed the
+	// decision; it waits in the appeal queue until an administrator decides.
+	ReviewStatusAppealed ReviewStatus = "appealed"
+	// ReviewStatusWithdrawn marks a pending review the author pulled from the
+	// moderation queue; it is kept and re-enters moderation when edited or
+	// submitted again.
+	ReviewStatusWithdrawn ReviewStatus = "withdrawn"
 )
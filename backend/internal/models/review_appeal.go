@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReviewAppeal is an author's request to overturn the rejection of a review.
+// A review can be appealed once; the record keeps the outcome.
+type ReviewAppeal struct {
+	ID       uuid.UUID `gorm:"type:char(36);primaryKey" json:"id"`
+	ReviewID uuid.UUID `gorm:"type:char(36);uniqueIndex;not null" json:"review_id"`
+	AuthorID uuid.UUID `gorm:"type:char(36);not null" json:"author_id"`
+	Message  string    `gorm:"type:text;not null" json:"message"`
+	// RejectionReason is the reason being appealed, kept for the record.
+	RejectionReason string       `gorm:"type:text" json:"rejection_reason"`
+	Status          AppealStatus `gorm:"size:20;not null;default:open;index" json:"status"`
+	ResolverID      *uuid.UUID   `gorm:"type:char(36)" json:"resolver_id"`
+	Response        string       `gorm:"type:text" json:"response"`
+	ResolvedAt      *time.Time   `json:"resolved_at"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+}
+
+// BeforeCreate assigns a UUID if empty.
+func (a *ReviewAppeal) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// AppealStatus enumerates appeal outcomes.
+type AppealStatus string
+
+const (
+	AppealStatusOpen     AppealStatus = "open"
+	AppealStatusAccepted AppealStatus = "accepted"
+	AppealStatusDenied   AppealStatus = "denied"
+)
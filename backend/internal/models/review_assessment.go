@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReviewAssessment is the latest pre-moderation verdict on a review's
+// content awaiting moderation. It is kept for administrators and replaced
+// whenever the content is assessed again.
+type ReviewAssessment struct {
+	ReviewID uuid.UUID `gorm:"type:char(36);primaryKey" json:"review_id"`
+	// Target tells whether the review itself or its pending edit was assessed.
+	Target   AssessmentTarget `gorm:"size:16;not null" json:"target"`
+	Score    float64          `gorm:"not null" json:"score"`
+	Decision RiskDecision     `gorm:"size:16;not null;index" json:"decision"`
+	Findings []RiskFinding    `gorm:"serializer:json" json:"findings"`
+	// CreatedAt is when the content was assessed.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RiskFinding is one reason a pre-moderation check raised or lowered the
+// risk score.
+type RiskFinding struct {
+	Check  string  `json:"check"`
+	Score  float64 `json:"score"`
+	Reason string  `json:"reason"`
+}
+
+// RiskDecision enumerates pre-moderation outcomes.
+type RiskDecision string
+
+const (
+	RiskDecisionApprove RiskDecision = "approve"
+	RiskDecisionReject  RiskDecision = "reject"
+	// RiskDecisionReview leaves the decision to an administrator.
+	RiskDecisionReview RiskDecision = "review"
+)
+
+// AssessmentTarget names the content an assessment covers.
+type AssessmentTarget string
+
+const (
+	AssessmentTargetReview AssessmentTarget = "review"
+	AssessmentTargetEdit   AssessmentTarget = "edit"
+)
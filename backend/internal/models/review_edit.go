@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReviewEdit holds an author's proposed changes to an approved review. The
+// approved content stays public until an administrator approves the edit.
+type ReviewEdit struct {
+	ID          uuid.UUID        `gorm:"type:char(36);primaryKey" json:"id"`
+	ReviewID    uuid.UUID        `gorm:"type:char(36);uniqueIndex;not null" json:"review_id"`
+	EditorID    uuid.UUID        `gorm:"type:char(36);not null" json:"editor_id"`
+	Title       string           `gorm:"size:120;not null" json:"title"`
+	Address     string           `gorm:"size:255;not null" json:"address"`
+	Description string           `gorm:"type:text" json:"description"`
+	Rating      float32          `gorm:"type:decimal(2,1);not null" json:"rating"`
+	Ratings     DimensionRatings `gorm:"embedded;embeddedPrefix:rating_" json:"ratings"`
+	// ModerationFlags lists the flagged sensitive words found in the edit.
+	ModerationFlags []string `gorm:"serializer:json" json:"moderation_flags,omitempty"`
+	// Duplicates lists existing reviews with text similar to the edit.
+	Duplicates []DuplicateMatch `gorm:"serializer:json" json:"duplicates,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+}
+
+// BeforeCreate assigns a UUID if empty.
+func (e *ReviewEdit) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
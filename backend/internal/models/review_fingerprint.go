@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReviewFingerprint is the similarity fingerprint of the title and
+// description a review was last submitted with, used to find repeated
+// content.
+type ReviewFingerprint struct {
+	ReviewID uuid.UUID `gorm:"type:char(36);primaryKey"`
+	AuthorID uuid.UUID `gorm:"type:char(36);index;not null"`
+	// Hash is the 64-bit SimHash, stored as a signed integer for SQLite.
+	Hash int64 `gorm:"not null"`
+	// Digest is the SHA-256 of the canonical text, shared by exact copies.
+	Digest string `gorm:"size:64;index;not null"`
+	// Length is the number of runes in the canonical text.
+	Length    int       `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"index"`
+}
+
+// DuplicateMatch links content awaiting moderation to an existing review
+// with the same or nearly the same text.
+type DuplicateMatch struct {
+	ReviewID uuid.UUID    `json:"review_id"`
+	Title    string       `json:"title"`
+	Status   ReviewStatus `json:"status"`
+	// Own is set when the matched review has the same author.
+	Own bool `json:"own"`
+	// Exact is set when the texts are identical after canonicalisation.
+	Exact bool `json:"exact"`
+	// Distance is the number of differing fingerprint bits, 0 to 64.
+	Distance int `json:"distance"`
+}
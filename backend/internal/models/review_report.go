@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReportReason categorises why a reader reported a review.
+type ReportReason string
+
+const (
+	ReportReasonSpam       ReportReason = "spam"
+	ReportReasonOffensive  ReportReason = "offensive"
+	ReportReasonInaccurate ReportReason = "inaccurate"
+	ReportReasonIrrelevant ReportReason = "irrelevant"
+	ReportReasonPrivacy    ReportReason = "privacy"
+	// ReportReasonOther requires the reporter to describe the problem.
+	ReportReasonOther ReportReason = "other"
+)
+
+// Valid reports whether r is a known reason.
+func (r ReportReason) Valid() bool {
+	switch r {
+	case ReportReasonSpam, ReportReasonOffensive, ReportReasonInaccurate, ReportReasonIrrelevant, ReportReasonPrivacy, ReportReasonOther:
+		return true
+	}
+	return false
+}
+
+// ReportStatus enumerates report handling states.
+type ReportStatus string
+
+const (
+	ReportStatusOpen ReportStatus = "open"
+	// ReportStatusResolved means the report was upheld.
+	ReportStatusResolved ReportStatus = "resolved"
+	// ReportStatusDismissed means the report was found unwarranted.
+	ReportStatusDismissed ReportStatus = "dismissed"
+)
+
+// ReviewReport is a reader's complaint about a published review. Each user
+// may report a review once.
+type ReviewReport struct {
+	ID         uuid.UUID    `gorm:"type:char(36);primaryKey" json:"id"`
+	ReviewID   uuid.UUID    `gorm:"type:char(36);not null;uniqueIndex:idx_review_reporter" json:"review_id"`
+	Review     *Review      `gorm:"foreignKey:ReviewID" json:"review,omitempty"`
+	ReporterID uuid.UUID    `gorm:"type:char(36);not null;uniqueIndex:idx_review_reporter" json:"reporter_id"`
+	Reporter   *User        `gorm:"foreignKey:ReporterID" json:"reporter,omitempty"`
+	Reason     ReportReason `gorm:"size:20;not null;index" json:"reason"`
+	Details    string       `gorm:"type:text" json:"details"`
+	Status     ReportStatus `gorm:"size:20;not null;default:open;index" json:"status"`
+	// ResolverID is the administrator who handled the report; nil while open
+	// and for reports closed by the system.
+	ResolverID *uuid.UUID `gorm:"type:char(36)" json:"resolver_id,omitempty"`
+	Note       string     `gorm:"type:text" json:"note,omitempty"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt  time.Time  `gorm:"index" json:"created_at"`
+}
+
+// BeforeCreate assigns a UUID if empty.
+func (r *ReviewReport) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
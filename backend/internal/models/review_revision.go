@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReviewRevision is an immutable record of one change to a review's content.
+type ReviewRevision struct {
+	ID       uuid.UUID `gorm:"type:char(36);primaryKey" json:"id"`
+	ReviewID uuid.UUID `gorm:"type:char(36);index;not null" json:"review_id"`
+	EditorID uuid.UUID `gorm:"type:char(36);not null" json:"editor_id"`
+	Editor   User      `gorm:"foreignKey:EditorID" json:"editor"`
+	// Kind tells whether the change was applied directly or proposed as a
+	// pending edit of an approved review.
+	Kind      RevisionKind  `gorm:"size:20;not null" json:"kind"`
+	Changes   []FieldChange `gorm:"serializer:json;type:text" json:"changes"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// BeforeCreate assigns a UUID if empty.
+func (r *ReviewRevision) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// RevisionKind enumerates how a revision affected the review.
+type RevisionKind string
+
+const (
+	RevisionKindApplied  RevisionKind = "applied"
+	RevisionKindProposed RevisionKind = "proposed"
+)
+
+// FieldChange describes one field's value before and after a change.
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
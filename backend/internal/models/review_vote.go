@@ -0,0 +1,60 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReviewVote records whether a reader found a published review helpful. Each
+// reader holds at most one vote per review.
+type ReviewVote struct {
+	ID        uuid.UUID `gorm:"type:char(36);primaryKey" json:"id"`
+	ReviewID  uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_review_voter" json:"review_id"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_review_voter;index" json:"user_id"`
+	Helpful   bool      `gorm:"not null" json:"helpful"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BeforeCreate assigns a UUID if empty.
+func (v *ReviewVote) BeforeCreate(tx *gorm.DB) error {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return nil
+}
+
+// wilsonZ is the normal quantile for the 95% confidence level used when
+// ranking reviews by helpfulness.
+const wilsonZ = 1.96
+
+// ReviewVoteStats holds the vote tallies of a review. They are recounted from
+// the votes whenever one changes.
+type ReviewVoteStats struct {
+	HelpfulCount   int64 `gorm:"not null;default:0" json:"helpful_count"`
+	UnhelpfulCount int64 `gorm:"not null;default:0" json:"unhelpful_count"`
+	// HelpfulScore is the lower bound of the Wilson score interval for the
+	// share of helpful votes, so a review with 40 of 50 helpful votes outranks
+	// one with a single helpful vote.
+	HelpfulScore float64 `gorm:"not null;default:0;index" json:"helpful_score"`
+}
+
+// Reset replaces the tallies and recomputes the score.
+func (s *ReviewVoteStats) Reset(helpful, unhelpful int64) {
+	s.HelpfulCount = helpful
+	s.UnhelpfulCount = unhelpful
+	s.HelpfulScore = 0
+
+	n := float64(helpful + unhelpful)
+	if n == 0 {
+		return
+	}
+	p := float64(helpful) / n
+	z2 := wilsonZ * wilsonZ
+	centre := p + z2/(2*n)
+	margin := wilsonZ * math.Sqrt((p*(1-p)+z2/(4*n))/n)
+	s.HelpfulScore = (centre - margin) / (1 + z2/n)
+}
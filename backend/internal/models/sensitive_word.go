@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SensitiveWord is an entry of the word list reviews are screened against
+// before they reach moderators.
+type SensitiveWord struct {
+	ID   uuid.UUID `gorm:"type:char(36);primaryKey" json:"id"`
+	Word string    `gorm:"size:64;uniqueIndex;not null" json:"word"`
+	// Variants are other spellings of the word, such as its pinyin or an
+	// abbreviation, matched as the same word.
+	Variants []string `gorm:"serializer:json" json:"variants"`
+	// Category groups words for administrators, e.g. "profanity", "ads" or
+	// "contact".
+	Category  string              `gorm:"size:32;index" json:"category"`
+	Action    SensitiveWordAction `gorm:"size:16;not null" json:"action"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+// BeforeCreate assigns a UUID if empty.
+func (w *SensitiveWord) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return nil
+}
+
+// SensitiveWordAction enumerates how content containing a word is handled.
+type SensitiveWordAction string
+
+const (
+	// SensitiveWordBlock rejects the submission.
+	SensitiveWordBlock SensitiveWordAction = "block"
+	// SensitiveWordMask replaces the word with asterisks.
+	SensitiveWordMask SensitiveWordAction = "mask"
+	// SensitiveWordFlag accepts the submission but flags it for moderators.
+	SensitiveWordFlag SensitiveWordAction = "flag"
+)
@@ -0,0 +1,182 @@
+package moderation
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/models"
+	"github.com/hdu-dp/backend/internal/textfilter"
+)
+
+// Scores contributed by the built-in checks.
+const (
+	flaggedWordScore        = 0.3
+	linkScore               = 0.5
+	contactScore            = 0.4
+	ownDuplicateScore       = 0.9
+	ownNearDuplicateScore   = 0.6
+	otherDuplicateScore     = 0.6
+	otherNearDuplicateScore = 0.4
+	newAuthorScore          = 0.2
+	frequentRejectScore     = 0.3
+	trustedAuthorScore      = -0.2
+	trustedAuthorMinimum    = 5
+)
+
+// WordCheck reports words the sensitive-word filter flags. Blocked words
+// never reach the pipeline and masked ones are already replaced.
+type WordCheck struct {
+	filter *textfilter.Filter
+}
+
+// NewWordCheck constructs a check backed by filter.
+func NewWordCheck(filter *textfilter.Filter) *WordCheck {
+	return &WordCheck{filter: filter}
+}
+
+// Name implements Check.
+func (c *WordCheck) Name() string { return "words" }
+
+// Run implements Check.
+func (c *WordCheck) Run(subject Subject) ([]models.RiskFinding, error) {
+	var findings []models.RiskFinding
+	for _, rule := range c.filter.Screen(subject.Text()).Flagged {
+		reason := "flagged word " + rule.Word
+		if rule.Category != "" {
+			reason = fmt.Sprintf("flagged %s word %s", rule.Category, rule.Word)
+		}
+		findings = append(findings, models.RiskFinding{Score: flaggedWordScore, Reason: reason})
+	}
+	return findings, nil
+}
+
+var (
+	linkPattern = regexp.MustCompile(`(?i)(?:https?://|www\.)\S+|\b[a-z0-9][a-z0-9-]*\.(?:com|cn|net|org|top|xyz|cc|io|me|vip|shop)\b`)
+	// phonePattern matches mainland mobile numbers, qqPattern QQ numbers
+	// announced as such.
+	phonePattern = regexp.MustCompile(`(?:^|\D)1[3-9]\d{9}(?:\D|$)`)
+	qqPattern    = regexp.MustCompile(`(?i)(?:qq|扣扣|企鹅)\D{0,4}\d{5,11}`)
+)
+
+// LinkCheck reports links and contact details, which in campus reviews
+// almost always mean advertising.
+type LinkCheck struct{}
+
+// NewLinkCheck constructs a link check.
+func NewLinkCheck() *LinkCheck { return &LinkCheck{} }
+
+// Name implements Check.
+func (c *LinkCheck) Name() string { return "links" }
+
+// Run implements Check.
+func (c *LinkCheck) Run(subject Subject) ([]models.RiskFinding, error) {
+	text := textfilter.Fold(subject.Text())
+
+	var findings []models.RiskFinding
+	if link := linkPattern.FindString(text); link != "" {
+		findings = append(findings, models.RiskFinding{Score: linkScore, Reason: "contains link " + link})
+	}
+	if phonePattern.MatchString(text) || qqPattern.MatchString(text) {
+		findings = append(findings, models.RiskFinding{Score: contactScore, Reason: "contains contact details"})
+	}
+	return findings, nil
+}
+
+// DuplicateCheck reports content that repeats earlier reviews, either by the
+// same author or copied from someone else. It scores the matches found when
+// the content was submitted.
+type DuplicateCheck struct{}
+
+// NewDuplicateCheck constructs a duplicate check.
+func NewDuplicateCheck() *DuplicateCheck {
+	return &DuplicateCheck{}
+}
+
+// Name implements Check.
+func (c *DuplicateCheck) Name() string { return "duplicates" }
+
+// Run implements Check. Only the strongest match of each kind counts.
+func (c *DuplicateCheck) Run(subject Subject) ([]models.RiskFinding, error) {
+	var own, others *models.DuplicateMatch
+	for i := range subject.Duplicates {
+		match := &subject.Duplicates[i]
+		best := &others
+		if match.Own {
+			best = &own
+		}
+		if *best == nil || closerMatch(match, *best) {
+			*best = match
+		}
+	}
+
+	var findings []models.RiskFinding
+	if own != nil {
+		score := ownNearDuplicateScore
+		if own.Exact {
+			score = ownDuplicateScore
+		}
+		findings = append(findings, models.RiskFinding{Score: score, Reason: duplicateReason(own, "an earlier review by the author")})
+	}
+	if others != nil {
+		score := otherNearDuplicateScore
+		if others.Exact {
+			score = otherDuplicateScore
+		}
+		findings = append(findings, models.RiskFinding{Score: score, Reason: duplicateReason(others, "a review by another author")})
+	}
+	return findings, nil
+}
+
+func closerMatch(a, b *models.DuplicateMatch) bool {
+	if a.Exact != b.Exact {
+		return a.Exact
+	}
+	return a.Distance < b.Distance
+}
+
+func duplicateReason(match *models.DuplicateMatch, whose string) string {
+	if match.Exact {
+		return fmt.Sprintf("same text as %s (%s)", whose, match.ReviewID)
+	}
+	return fmt.Sprintf("similar to %s (%s, distance %d)", whose, match.ReviewID, match.Distance)
+}
+
+// AuthorHistory reports how an author's earlier reviews were moderated.
+type AuthorHistory interface {
+	// AuthorRecord counts the author's approved and rejected reviews other
+	// than the one given.
+	AuthorRecord(authorID, exclude uuid.UUID) (approved, rejected int64, err error)
+}
+
+// ReputationCheck raises the risk for new or frequently rejected authors and
+// lowers it for authors with a clean record.
+type ReputationCheck struct {
+	history AuthorHistory
+}
+
+// NewReputationCheck constructs a check backed by history.
+func NewReputationCheck(history AuthorHistory) *ReputationCheck {
+	return &ReputationCheck{history: history}
+}
+
+// Name implements Check.
+func (c *ReputationCheck) Name() string { return "reputation" }
+
+// Run implements Check.
+func (c *ReputationCheck) Run(subject Subject) ([]models.RiskFinding, error) {
+	approved, rejected, err := c.history.AuthorRecord(subject.AuthorID, subject.ReviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case rejected >= 2 && rejected >= approved:
+		return []models.RiskFinding{{Score: frequentRejectScore, Reason: fmt.Sprintf("author has %d rejected and %d approved reviews", rejected, approved)}}, nil
+	case approved == 0:
+		return []models.RiskFinding{{Score: newAuthorScore, Reason: "author has no approved reviews"}}, nil
+	case approved >= trustedAuthorMinimum && rejected == 0:
+		return []models.RiskFinding{{Score: trustedAuthorScore, Reason: fmt.Sprintf("author has %d approved reviews and none rejected", approved)}}, nil
+	}
+	return nil, nil
+}
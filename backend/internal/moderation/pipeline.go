@@ -0,0 +1,114 @@
+// Package moderation assesses reviews before they reach the admin queue. A
+// pipeline runs pluggable checks, adds up their findings into a risk score
+// and applies a policy that approves, rejects or queues the review.
+package moderation
+
+import (
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/models"
+)
+
+// Subject is the content being assessed: a new or resubmitted review, or an
+// edit proposed for an approved one.
+type Subject struct {
+	ReviewID    uuid.UUID
+	AuthorID    uuid.UUID
+	Title       string
+	Address     string
+	Description string
+	// Duplicates are the earlier reviews found to match the content.
+	Duplicates []models.DuplicateMatch
+}
+
+// Text joins the free-text fields of the subject for checks that scan them
+// together.
+func (s Subject) Text() string {
+	return s.Title + "\n" + s.Address + "\n" + s.Description
+}
+
+// Check inspects a subject and reports its findings. Findings with a positive
+// score raise the risk, negative ones lower it.
+type Check interface {
+	Name() string
+	Run(subject Subject) ([]models.RiskFinding, error)
+}
+
+// Policy turns a risk score into a decision. A zero threshold disables the
+// corresponding automatic decision.
+type Policy struct {
+	// ApproveBelow auto-approves subjects scoring strictly below it.
+	ApproveBelow float64
+	// RejectAt auto-rejects subjects scoring at or above it.
+	RejectAt float64
+}
+
+// Decide returns the decision for score.
+func (p Policy) Decide(score float64) models.RiskDecision {
+	switch {
+	case p.RejectAt > 0 && score >= p.RejectAt:
+		return models.RiskDecisionReject
+	case p.ApproveBelow > 0 && score < p.ApproveBelow:
+		return models.RiskDecisionApprove
+	default:
+		return models.RiskDecisionReview
+	}
+}
+
+// Assessment is the outcome of running the pipeline on a subject.
+type Assessment struct {
+	Score    float64
+	Decision models.RiskDecision
+	Findings []models.RiskFinding
+}
+
+// Pipeline runs checks in order and applies a policy to their combined score.
+type Pipeline struct {
+	policy Policy
+	checks []Check
+}
+
+// NewPipeline constructs a pipeline from a policy and the checks to run.
+func NewPipeline(policy Policy, checks ...Check) *Pipeline {
+	return &Pipeline{policy: policy, checks: checks}
+}
+
+// Assess runs every check on subject. The risk score is the sum of the
+// finding scores clamped to [0, 1] and rounded to hundredths, so that sums
+// such as 0.1+0.2 compare with thresholds as written. If a check fails the
+// subject is always queued for an administrator, with the failure among the
+// findings.
+func (p *Pipeline) Assess(subject Subject) Assessment {
+	var (
+		findings []models.RiskFinding
+		score    float64
+		failed   bool
+	)
+	for _, check := range p.checks {
+		found, err := check.Run(subject)
+		if err != nil {
+			log.Printf("moderation check %s on review %s: %v", check.Name(), subject.ReviewID, err)
+			failed = true
+			findings = append(findings, models.RiskFinding{
+				Check:  check.Name(),
+				Reason: fmt.Sprintf("check failed: %v", err),
+			})
+			continue
+		}
+		for _, f := range found {
+			f.Check = check.Name()
+			score += f.Score
+			findings = append(findings, f)
+		}
+	}
+
+	score = math.Round(min(max(score, 0), 1)*100) / 100
+	decision := p.policy.Decide(score)
+	if failed {
+		decision = models.RiskDecisionReview
+	}
+	return Assessment{Score: score, Decision: decision, Findings: findings}
+}
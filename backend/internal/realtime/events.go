@@ -0,0 +1,76 @@
+package realtime
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/hdu-dp/backend/internal/models"
+	"github.com/hdu-dp/backend/internal/workflow"
+)
+
+// Event names pushed to clients.
+const (
+	// EventReviewStatus tells an author that their review changed status.
+	EventReviewStatus = "review_status"
+	// EventReviewQueued and EventReviewDequeued tell administrators that a
+	// review or edit entered or left a moderation queue.
+	EventReviewQueued   = "review_queued"
+	EventReviewDequeued = "review_dequeued"
+	// EventNotification carries a newly recorded notification to its
+	// recipient.
+	EventNotification = "notification"
+)
+
+// OnReviewEvent pushes review workflow events: status changes to the author
+// and moderation queue changes to administrators. It is meant to be
+// subscribed to the review workflow machine.
+func (h *Hub) OnReviewEvent(event workflow.Event) {
+	if event.From != event.To {
+		h.publishJSON(Message{Event: EventReviewStatus, UserID: event.AuthorID}, event)
+	}
+	switch {
+	case entersQueue(event):
+		h.publishJSON(Message{Event: EventReviewQueued, Admins: true}, event)
+	case leavesQueue(event):
+		h.publishJSON(Message{Event: EventReviewDequeued, Admins: true}, event)
+	}
+}
+
+// OnNotification pushes a notification to its recipient. It is meant to be
+// subscribed to the notification service.
+func (h *Hub) OnNotification(notification models.Notification) {
+	h.publishJSON(Message{Event: EventNotification, UserID: notification.UserID}, notification)
+}
+
+func (h *Hub) publishJSON(m Message, payload any) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("encode %s event: %v", m.Event, err)
+		return
+	}
+	m.Data = data
+	h.Publish(m)
+}
+
+// queued reports whether reviews in status wait for an administrator.
+func queued(status models.ReviewStatus) bool {
+	return status == models.ReviewStatusPending || status == models.ReviewStatusAppealed
+}
+
+func entersQueue(event workflow.Event) bool {
+	switch event.Action {
+	case workflow.ActionProposeEdit:
+		return true
+	case workflow.ActionRestore:
+		return queued(event.To)
+	}
+	return queued(event.To) && event.From != event.To
+}
+
+func leavesQueue(event workflow.Event) bool {
+	switch event.Action {
+	case workflow.ActionApproveEdit, workflow.ActionRejectEdit, workflow.ActionWithdrawEdit, workflow.ActionDelete:
+		return true
+	}
+	return queued(event.From) && event.From != event.To
+}
@@ -0,0 +1,167 @@
+// Package realtime pushes events to connected clients. A Hub fans messages
+// out to subscribers in process and keeps a short backlog so that clients
+// reconnecting with the ID of the last event they saw can catch up.
+package realtime
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// subscriberBuffer is how many messages may wait for a slow client before it
+// is disconnected; it catches up from the backlog when it reconnects.
+const subscriberBuffer = 64
+
+// Message is an event for connected clients. It is delivered to UserID, to
+// administrators when Admins is set, or to both.
+type Message struct {
+	// ID is assigned by the hub when the message is published.
+	ID     string
+	Event  string
+	Data   []byte
+	UserID uuid.UUID
+	Admins bool
+
+	seq uint64
+}
+
+// Hub distributes messages to subscriptions. Message IDs are unique to a hub
+// instance, so an ID issued before a restart is recognised as stale.
+type Hub struct {
+	// epoch prefixes message IDs to tell hub instances apart.
+	epoch   string
+	backlog int
+
+	mu          sync.Mutex
+	seq         uint64
+	recent      []Message
+	subscribers map[*Subscription]struct{}
+}
+
+// NewHub constructs a hub that remembers the last backlog messages for
+// replay.
+func NewHub(backlog int) *Hub {
+	return &Hub{
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		backlog:     backlog,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscription is a client's feed of messages from a hub.
+type Subscription struct {
+	userID uuid.UUID
+	admin  bool
+	ch     chan Message
+
+	// Missed holds the messages published since the Last-Event-ID the client
+	// sent, oldest first.
+	Missed []Message
+	// Resumed reports whether Missed is complete. It is false for a stale or
+	// unknown ID, in which case the client should reload its state.
+	Resumed bool
+	// Head is the ID of the latest message at the time of subscribing.
+	Head string
+}
+
+// C delivers messages as they are published. It is closed when the
+// subscription ends, including when the client falls too far behind.
+func (s *Subscription) C() <-chan Message {
+	return s.ch
+}
+
+func (s *Subscription) wants(m Message) bool {
+	return (m.Admins && s.admin) || (m.UserID != uuid.Nil && m.UserID == s.userID)
+}
+
+// Publish assigns the message an ID, records it in the backlog and hands it
+// to the matching subscribers without waiting for them.
+func (h *Hub) Publish(m Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.seq++
+	m.seq = h.seq
+	m.ID = h.id(h.seq)
+	if h.backlog > 0 {
+		h.recent = append(h.recent, m)
+		if len(h.recent) > h.backlog {
+			h.recent = h.recent[len(h.recent)-h.backlog:]
+		}
+	}
+
+	for sub := range h.subscribers {
+		if !sub.wants(m) {
+			continue
+		}
+		select {
+		case sub.ch <- m:
+		default:
+			h.drop(sub)
+		}
+	}
+}
+
+// Subscribe registers a client. When lastEventID is not empty the messages
+// the client missed since then are returned in the subscription.
+func (h *Hub) Subscribe(userID uuid.UUID, admin bool, lastEventID string) *Subscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sub := &Subscription{
+		userID:  userID,
+		admin:   admin,
+		ch:      make(chan Message, subscriberBuffer),
+		Resumed: true,
+		Head:    h.id(h.seq),
+	}
+	if lastEventID != "" {
+		sub.Missed, sub.Resumed = h.since(sub, lastEventID)
+	}
+	h.subscribers[sub] = struct{}{}
+	return sub
+}
+
+// Unsubscribe ends a subscription. It is safe to call more than once.
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.drop(sub)
+}
+
+func (h *Hub) drop(sub *Subscription) {
+	if _, ok := h.subscribers[sub]; !ok {
+		return
+	}
+	delete(h.subscribers, sub)
+	close(sub.ch)
+}
+
+// since returns the backlogged messages for sub published after lastEventID
+// and whether the backlog still covers everything after it.
+func (h *Hub) since(sub *Subscription, lastEventID string) ([]Message, bool) {
+	epoch, rawSeq, ok := strings.Cut(lastEventID, "-")
+	if !ok || epoch != h.epoch {
+		return nil, false
+	}
+	seq, err := strconv.ParseUint(rawSeq, 10, 64)
+	if err != nil || seq > h.seq || h.seq-seq > uint64(len(h.recent)) {
+		return nil, false
+	}
+
+	var missed []Message
+	for _, m := range h.recent {
+		if m.seq > seq && sub.wants(m) {
+			missed = append(missed, m)
+		}
+	}
+	return missed, true
+}
+
+func (h *Hub) id(seq uint64) string {
+	return h.epoch + "-" + strconv.FormatUint(seq, 10)
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CommentRepository persists comments on reviews.
+type CommentRepository struct {
+	db *gorm.DB
+}
+
+// NewCommentRepository constructs a comment repository.
+func NewCommentRepository(db *gorm.DB) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+// WithTx returns a repository bound to the provided transaction handle.
+func (r *CommentRepository) WithTx(tx *gorm.DB) *CommentRepository {
+	return &CommentRepository{db: tx}
+}
+
+// Create stores a new comment.
+func (r *CommentRepository) Create(comment *models.Comment) error {
+	return r.db.Omit(clause.Associations).Create(comment).Error
+}
+
+// Update persists changes to a comment, leaving its author and replies alone.
+func (r *CommentRepository) Update(comment *models.Comment) error {
+	return r.db.Omit(clause.Associations).Save(comment).Error
+}
+
+// Delete soft-deletes a comment together with its replies.
+func (r *CommentRepository) Delete(comment *models.Comment) error {
+	return r.db.Where("id = ? OR parent_id = ?", comment.ID, comment.ID).Delete(&models.Comment{}).Error
+}
+
+// FindByID returns a comment with its author.
+func (r *CommentRepository) FindByID(id uuid.UUID) (*models.Comment, error) {
+	var comment models.Comment
+	if err := r.db.Preload("Author").First(&comment, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// CommentListOptions filters comment listings.
+type CommentListOptions struct {
+	ReviewID *uuid.UUID
+	AuthorID *uuid.UUID
+	Status   models.CommentStatus
+	// TopLevel keeps comments that are not replies and preloads their
+	// replies with the same status.
+	TopLevel bool
+	// FlaggedOnly keeps comments with moderation flags.
+	FlaggedOnly bool
+	// NewestFirst reverses the default oldest-first order.
+	NewestFirst bool
+	Limit       int
+	Offset      int
+}
+
+// CommentListResult is a page of comments.
+type CommentListResult struct {
+	Comments []models.Comment
+	Total    int64
+}
+
+// List returns matching comments with their authors, oldest first unless
+// NewestFirst is set.
+func (r *CommentRepository) List(opts CommentListOptions) (CommentListResult, error) {
+	base := r.db.Model(&models.Comment{})
+	if opts.ReviewID != nil {
+		base = base.Where("review_id = ?", opts.ReviewID)
+	}
+	if opts.AuthorID != nil {
+		base = base.Where("author_id = ?", opts.AuthorID)
+	}
+	if opts.Status != "" {
+		base = base.Where("status = ?", opts.Status)
+	}
+	if opts.TopLevel {
+		base = base.Where("parent_id IS NULL")
+	}
+	if opts.FlaggedOnly {
+		// Flags are stored as a JSON array; a non-empty one starts with `["`.
+		base = base.Where(`moderation_flags LIKE '["%'`)
+	}
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return CommentListResult{}, err
+	}
+
+	order := "created_at ASC"
+	if opts.NewestFirst {
+		order = "created_at DESC"
+	}
+	listQuery := base.Session(&gorm.Session{}).Preload("Author").Order(order)
+	if opts.TopLevel {
+		listQuery = listQuery.Preload("Replies", func(db *gorm.DB) *gorm.DB {
+			if opts.Status != "" {
+				db = db.Where("status = ?", opts.Status)
+			}
+			return db.Order("created_at ASC")
+		}).Preload("Replies.Author")
+	}
+	if opts.Limit > 0 {
+		listQuery = listQuery.Limit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		listQuery = listQuery.Offset(opts.Offset)
+	}
+
+	var comments []models.Comment
+	if err := listQuery.Find(&comments).Error; err != nil {
+		return CommentListResult{}, err
+	}
+	return CommentListResult{Comments: comments, Total: total}, nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FavoriteRepository persists users' saved reviews and places.
+type FavoriteRepository struct {
+	db *gorm.DB
+}
+
+// NewFavoriteRepository constructs a favorite repository.
+func NewFavoriteRepository(db *gorm.DB) *FavoriteRepository {
+	return &FavoriteRepository{db: db}
+}
+
+// targetColumn is the column holding the ID of what a favorite points at.
+func targetColumn(favType models.FavoriteType) string {
+	if favType == models.FavoriteTypePlace {
+		return "place_id"
+	}
+	return "review_id"
+}
+
+// Create stores a favorite unless the user already saved the same target.
+func (r *FavoriteRepository) Create(favorite *models.Favorite) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Omit(clause.Associations).Create(favorite).Error
+}
+
+// Delete removes the user's favorite of a target, if any.
+func (r *FavoriteRepository) Delete(userID uuid.UUID, favType models.FavoriteType, targetID uuid.UUID) error {
+	return r.db.Where("user_id = ? AND "+targetColumn(favType)+" = ?", userID, targetID).Delete(&models.Favorite{}).Error
+}
+
+// FavoritedIDs returns which of the given targets the user saved.
+func (r *FavoriteRepository) FavoritedIDs(userID uuid.UUID, favType models.FavoriteType, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+	favorited := make(map[uuid.UUID]bool, len(ids))
+	if len(ids) == 0 {
+		return favorited, nil
+	}
+	column := targetColumn(favType)
+	var found []uuid.UUID
+	if err := r.db.Model(&models.Favorite{}).
+		Where("user_id = ? AND "+column+" IN ?", userID, ids).
+		Pluck(column, &found).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range found {
+		favorited[id] = true
+	}
+	return favorited, nil
+}
+
+// FavoriteListOptions filters a user's favorites.
+type FavoriteListOptions struct {
+	UserID uuid.UUID
+	Type   models.FavoriteType
+	Limit  int
+	Offset int
+}
+
+// FavoriteListResult is a page of favorites.
+type FavoriteListResult struct {
+	Favorites []models.Favorite
+	Total     int64
+}
+
+// List returns a user's favorites, most recently saved first, with their
+// reviews and places. Reviews that are no longer published are left out.
+func (r *FavoriteRepository) List(opts FavoriteListOptions) (FavoriteListResult, error) {
+	base := r.db.Model(&models.Favorite{}).
+		Where("favorites.user_id = ?", opts.UserID).
+		Where("favorites.review_id IS NULL OR EXISTS (SELECT 1 FROM reviews WHERE reviews.id = favorites.review_id AND reviews.status = ? AND reviews.deleted_at IS NULL)", models.ReviewStatusApproved)
+	if opts.Type != "" {
+		base = base.Where("favorites.type = ?", opts.Type)
+	}
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return FavoriteListResult{}, err
+	}
+
+	listQuery := base.Session(&gorm.Session{}).
+		Preload("Review").Preload("Review.Images").Preload("Review.Author").Preload("Review.Place").
+		Preload("Place").
+		Order("favorites.created_at DESC")
+	if opts.Limit > 0 {
+		listQuery = listQuery.Limit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		listQuery = listQuery.Offset(opts.Offset)
+	}
+
+	var favorites []models.Favorite
+	if err := listQuery.Find(&favorites).Error; err != nil {
+		return FavoriteListResult{}, err
+	}
+	return FavoriteListResult{Favorites: favorites, Total: total}, nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FollowRepository persists the follow graph between users.
+type FollowRepository struct {
+	db *gorm.DB
+}
+
+// NewFollowRepository constructs a follow repository.
+func NewFollowRepository(db *gorm.DB) *FollowRepository {
+	return &FollowRepository{db: db}
+}
+
+// Create stores a follow unless the follower already follows the followee,
+// and reports whether it did.
+func (r *FollowRepository) Create(follow *models.Follow) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Omit(clause.Associations).Create(follow)
+	return result.RowsAffected > 0, result.Error
+}
+
+// Delete removes a follow, if any.
+func (r *FollowRepository) Delete(followerID, followeeID uuid.UUID) error {
+	return r.db.Where("follower_id = ? AND followee_id = ?", followerID, followeeID).Delete(&models.Follow{}).Error
+}
+
+// FollowListResult is a page of follows.
+type FollowListResult struct {
+	Follows []models.Follow
+	Total   int64
+}
+
+// ListFollowers returns who follows the user, most recent first, with the
+// followers loaded.
+func (r *FollowRepository) ListFollowers(userID uuid.UUID, limit, offset int) (FollowListResult, error) {
+	return r.list("followee_id", userID, "Follower", limit, offset)
+}
+
+// ListFollowing returns who the user follows, most recent first, with the
+// followees loaded.
+func (r *FollowRepository) ListFollowing(userID uuid.UUID, limit, offset int) (FollowListResult, error) {
+	return r.list("follower_id", userID, "Followee", limit, offset)
+}
+
+func (r *FollowRepository) list(column string, userID uuid.UUID, preload string, limit, offset int) (FollowListResult, error) {
+	base := r.db.Model(&models.Follow{}).Where(column+" = ?", userID)
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return FollowListResult{}, err
+	}
+
+	listQuery := base.Session(&gorm.Session{}).Preload(preload).Order("created_at DESC")
+	if limit > 0 {
+		listQuery = listQuery.Limit(limit)
+	}
+	if offset > 0 {
+		listQuery = listQuery.Offset(offset)
+	}
+
+	var follows []models.Follow
+	if err := listQuery.Find(&follows).Error; err != nil {
+		return FollowListResult{}, err
+	}
+	return FollowListResult{Follows: follows, Total: total}, nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// ModerationActionRepository persists the moderation audit log.
+type ModerationActionRepository struct {
+	db *gorm.DB
+}
+
+// NewModerationActionRepository constructs a moderation action repository.
+func NewModerationActionRepository(db *gorm.DB) *ModerationActionRepository {
+	return &ModerationActionRepository{db: db}
+}
+
+// WithTx returns a repository bound to the provided transaction handle.
+func (r *ModerationActionRepository) WithTx(tx *gorm.DB) *ModerationActionRepository {
+	return &ModerationActionRepository{db: tx}
+}
+
+// Create records a moderation action.
+func (r *ModerationActionRepository) Create(action *models.ModerationAction) error {
+	return r.db.Create(action).Error
+}
+
+// ModerationActionListOptions filters the audit log.
+type ModerationActionListOptions struct {
+	ReviewID *uuid.UUID
+	AuthorID *uuid.UUID
+	ActorID  *uuid.UUID
+	Action   string
+	// From and To bound created_at inclusively when non-zero.
+	From   time.Time
+	To     time.Time
+	Limit  int
+	Offset int
+}
+
+// ModerationActionListResult is a page of audit records.
+type ModerationActionListResult struct {
+	Actions []models.ModerationAction
+	Total   int64
+}
+
+// List returns matching audit records, newest first.
+func (r *ModerationActionRepository) List(opts ModerationActionListOptions) (ModerationActionListResult, error) {
+	base := r.db.Model(&models.ModerationAction{})
+	if opts.ReviewID != nil {
+		base = base.Where("review_id = ?", opts.ReviewID)
+	}
+	if opts.AuthorID != nil {
+		base = base.Where("author_id = ?", opts.AuthorID)
+	}
+	if opts.ActorID != nil {
+		base = base.Where("actor_id = ?", opts.ActorID)
+	}
+	if opts.Action != "" {
+		base = base.Where("action = ?", opts.Action)
+	}
+	if !opts.From.IsZero() {
+		base = base.Where("created_at >= ?", opts.From)
+	}
+	if !opts.To.IsZero() {
+		base = base.Where("created_at <= ?", opts.To)
+	}
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return ModerationActionListResult{}, err
+	}
+
+	listQuery := base.Session(&gorm.Session{}).Preload("Actor").Order("created_at DESC")
+	if opts.Limit > 0 {
+		listQuery = listQuery.Limit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		listQuery = listQuery.Offset(opts.Offset)
+	}
+
+	var actions []models.ModerationAction
+	if err := listQuery.Find(&actions).Error; err != nil {
+		return ModerationActionListResult{}, err
+	}
+	return ModerationActionListResult{Actions: actions, Total: total}, nil
+}
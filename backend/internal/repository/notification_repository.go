@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// NotificationRepository persists users' notifications.
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository constructs a notification repository.
+func NewNotificationRepository(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// Create stores a notification.
+func (r *NotificationRepository) Create(notification *models.Notification) error {
+	return r.db.Create(notification).Error
+}
+
+// ExistsForComment reports whether the user was already notified of the
+// given type about a comment.
+func (r *NotificationRepository) ExistsForComment(userID uuid.UUID, notificationType models.NotificationType, commentID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND type = ? AND comment_id = ?", userID, notificationType, commentID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// NotificationListOptions filters a user's notifications.
+type NotificationListOptions struct {
+	UserID     uuid.UUID
+	UnreadOnly bool
+	Limit      int
+	Offset     int
+}
+
+// NotificationListResult is a page of notifications.
+type NotificationListResult struct {
+	Notifications []models.Notification
+	Total         int64
+}
+
+// List returns a user's notifications, newest first.
+func (r *NotificationRepository) List(opts NotificationListOptions) (NotificationListResult, error) {
+	base := r.db.Model(&models.Notification{}).Where("user_id = ?", opts.UserID)
+	if opts.UnreadOnly {
+		base = base.Where("read_at IS NULL")
+	}
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return NotificationListResult{}, err
+	}
+
+	listQuery := base.Session(&gorm.Session{}).Order("created_at DESC")
+	if opts.Limit > 0 {
+		listQuery = listQuery.Limit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		listQuery = listQuery.Offset(opts.Offset)
+	}
+
+	var notifications []models.Notification
+	if err := listQuery.Find(&notifications).Error; err != nil {
+		return NotificationListResult{}, err
+	}
+	return NotificationListResult{Notifications: notifications, Total: total}, nil
+}
+
+// CountUnread counts a user's unread notifications.
+func (r *NotificationRepository) CountUnread(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count).Error
+	return count, err
+}
+
+// FindByID returns a notification by ID.
+func (r *NotificationRepository) FindByID(id uuid.UUID) (*models.Notification, error) {
+	var notification models.Notification
+	if err := r.db.First(&notification, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+// MarkRead marks a notification read if it is not already.
+func (r *NotificationRepository) MarkRead(notification *models.Notification, at time.Time) error {
+	if notification.ReadAt != nil {
+		return nil
+	}
+	if err := r.db.Model(notification).Update("read_at", at).Error; err != nil {
+		return err
+	}
+	notification.ReadAt = &at
+	return nil
+}
+
+// MarkAllRead marks every unread notification of a user read and returns how
+// many there were.
+func (r *NotificationRepository) MarkAllRead(userID uuid.UUID, at time.Time) (int64, error) {
+	result := r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", at)
+	return result.RowsAffected, result.Error
+}
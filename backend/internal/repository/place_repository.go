@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// PlaceRepository manages persistence for places.
+type PlaceRepository struct {
+	db *gorm.DB
+}
+
+// NewPlaceRepository constructs a place repository.
+func NewPlaceRepository(db *gorm.DB) *PlaceRepository {
+	return &PlaceRepository{db: db}
+}
+
+// WithTx returns a repository bound to the provided transaction handle.
+func (r *PlaceRepository) WithTx(tx *gorm.DB) *PlaceRepository {
+	return &PlaceRepository{db: tx}
+}
+
+// PlaceListOptions holds query parameters for retrieving places.
+type PlaceListOptions struct {
+	Statuses   []models.PlaceStatus
+	CampusArea string
+	Category   string
+	Query      string
+	SortBy     string
+	SortDir    string
+	Limit      int
+	Offset     int
+}
+
+// PlaceListResult represents a paginated place resultset.
+type PlaceListResult struct {
+	Places []models.Place
+	Total  int64
+}
+
+// List fetches places using provided options.
+func (r *PlaceRepository) List(opts PlaceListOptions) (PlaceListResult, error) {
+	base := r.db.Model(&models.Place{})
+
+	if len(opts.Statuses) > 0 {
+		base = base.Where("status IN ?", opts.Statuses)
+	}
+	if opts.CampusArea != "" {
+		base = base.Where("campus_area = ?", opts.CampusArea)
+	}
+	if opts.Category != "" {
+		base = base.Where("category = ?", opts.Category)
+	}
+	if opts.Query != "" {
+		like := fmt.Sprintf("%%%s%%", opts.Query)
+		base = base.Where("name LIKE ? OR address LIKE ?", like, like)
+	}
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return PlaceListResult{}, err
+	}
+
+	sortBy := "created_at"
+	switch strings.ToLower(opts.SortBy) {
+	case "name":
+		sortBy = "name"
+	case "score":
+		sortBy = "score"
+	case "rating":
+		sortBy = "average_rating"
+	case "reviews":
+		sortBy = "review_count"
+	case "created_at":
+		sortBy = "created_at"
+	}
+
+	sortDir := "DESC"
+	if strings.EqualFold(opts.SortDir, "asc") {
+		sortDir = "ASC"
+	}
+
+	listQuery := base.Session(&gorm.Session{}).Order(fmt.Sprintf("%s %s", sortBy, sortDir))
+
+	if opts.Limit > 0 {
+		listQuery = listQuery.Limit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		listQuery = listQuery.Offset(opts.Offset)
+	}
+
+	var places []models.Place
+	if err := listQuery.Find(&places).Error; err != nil {
+		return PlaceListResult{}, err
+	}
+
+	return PlaceListResult{Places: places, Total: total}, nil
+}
+
+// matchPrefixRunes is how many leading runes a place's match key must share
+// with an address that it neither contains nor is contained in.
+const matchPrefixRunes = 2
+
+// MatchCandidates returns the places worth fuzzy-matching against key, an
+// address folded like Place.SyncMatchKeys does: those whose name or address
+// key starts with the same runes as key, contains it or is contained in it.
+func (r *PlaceRepository) MatchCandidates(key string) ([]models.Place, error) {
+	if key == "" {
+		return nil, nil
+	}
+	prefix := key
+	if runes := []rune(key); len(runes) > matchPrefixRunes {
+		prefix = string(runes[:matchPrefixRunes])
+	}
+
+	var places []models.Place
+	err := r.db.
+		Where("name_key LIKE ? OR address_key LIKE ?", prefix+"%", prefix+"%").
+		Or("instr(name_key, ?) > 0 OR instr(address_key, ?) > 0", key, key).
+		Or("(name_key <> '' AND instr(?, name_key) > 0) OR (address_key <> '' AND instr(?, address_key) > 0)", key, key).
+		Order("created_at ASC").
+		Find(&places).Error
+	if err != nil {
+		return nil, err
+	}
+	return places, nil
+}
+
+// Create inserts a new place.
+func (r *PlaceRepository) Create(place *models.Place) error {
+	place.SyncMatchKeys()
+	return r.db.Create(place).Error
+}
+
+// Update persists changes to the descriptive columns of a place. Rating
+// aggregates are left untouched; see UpdateStats.
+func (r *PlaceRepository) Update(place *models.Place) error {
+	place.SyncMatchKeys()
+	return r.db.Model(place).
+		Select("name", "address", "name_key", "address_key", "campus_area", "category", "status", "updated_at").
+		Updates(place).Error
+}
+
+// AdjustStats adds a rating to (delta 1) or removes one from (delta -1) the
+// aggregates of a place. Counts and sums are adjusted in place and the
+// derived columns recomputed from them in the same statement, so concurrent
+// adjustments of one place cannot overwrite each other.
+func (r *PlaceRepository) AdjustStats(placeID uuid.UUID, rating float32, delta int, prior models.BayesianPrior) error {
+	change := float64(delta) * float64(rating)
+	bucket := models.RatingBucketColumn(rating)
+	return r.db.Model(&models.Place{}).Where("id = ?", placeID).Updates(map[string]interface{}{
+		"review_count": gorm.Expr("MAX(review_count + ?, 0)", delta),
+		"rating_sum":   gorm.Expr("CASE WHEN review_count + ? > 0 THEN rating_sum + ? ELSE 0 END", delta, change),
+		"average_rating": gorm.Expr("CASE WHEN review_count + ? > 0 THEN ROUND((rating_sum + ?) / (review_count + ?), 2) ELSE 0 END",
+			delta, change, delta),
+		"score": gorm.Expr("CASE WHEN review_count + ? > 0 THEN ROUND((? + rating_sum + ?) / (? + review_count + ?), 2) ELSE 0 END",
+			delta, prior.Weight*prior.Mean, change, prior.Weight, delta),
+		bucket: gorm.Expr("MAX("+bucket+" + ?, 0)", delta),
+	}).Error
+}
+
+// UpdateStats persists only the rating aggregate columns of a place.
+func (r *PlaceRepository) UpdateStats(place *models.Place) error {
+	st := place.Stats
+	return r.db.Model(&models.Place{}).Where("id = ?", place.ID).Updates(map[string]interface{}{
+		"review_count":   st.ReviewCount,
+		"rating_sum":     st.RatingSum,
+		"average_rating": st.AverageRating,
+		"score":          st.Score,
+		"rating1_count":  st.Rating1Count,
+		"rating2_count":  st.Rating2Count,
+		"rating3_count":  st.Rating3Count,
+		"rating4_count":  st.Rating4Count,
+		"rating5_count":  st.Rating5Count,
+	}).Error
+}
+
+// ApprovedRatings returns the ratings of all approved reviews linked to a place.
+func (r *PlaceRepository) ApprovedRatings(placeID uuid.UUID) ([]float32, error) {
+	var ratings []float32
+	err := r.db.Model(&models.Review{}).
+		Where("place_id = ? AND status = ?", placeID, models.ReviewStatusApproved).
+		Pluck("rating", &ratings).Error
+	return ratings, err
+}
+
+// FindByID returns a place by UUID.
+func (r *PlaceRepository) FindByID(id uuid.UUID) (*models.Place, error) {
+	var place models.Place
+	if err := r.db.First(&place, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &place, nil
+}
+
+// Delete removes a place and the favorites of it, and detaches reviews that
+// referenced it.
+func (r *PlaceRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Review{}).Where("place_id = ?", id).Update("place_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("place_id = ?", id).Delete(&models.Favorite{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.Place{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		return nil
+	})
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// RejectionReasonRepository manages persistence for rejection templates.
+type RejectionReasonRepository struct {
+	db *gorm.DB
+}
+
+// NewRejectionReasonRepository constructs a rejection reason repository.
+func NewRejectionReasonRepository(db *gorm.DB) *RejectionReasonRepository {
+	return &RejectionReasonRepository{db: db}
+}
+
+// Create inserts a new rejection template.
+func (r *RejectionReasonRepository) Create(reason *models.RejectionReason) error {
+	return r.db.Create(reason).Error
+}
+
+// Update persists changes to a rejection template.
+func (r *RejectionReasonRepository) Update(reason *models.RejectionReason) error {
+	return r.db.Save(reason).Error
+}
+
+// Delete removes a rejection template.
+func (r *RejectionReasonRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.RejectionReason{}, "id = ?", id).Error
+}
+
+// FindByID retrieves a rejection template by ID.
+func (r *RejectionReasonRepository) FindByID(id uuid.UUID) (*models.RejectionReason, error) {
+	var reason models.RejectionReason
+	if err := r.db.First(&reason, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &reason, nil
+}
+
+// FindByCode retrieves a rejection template by code.
+func (r *RejectionReasonRepository) FindByCode(code string) (*models.RejectionReason, error) {
+	var reason models.RejectionReason
+	if err := r.db.First(&reason, "code = ?", code).Error; err != nil {
+		return nil, err
+	}
+	return &reason, nil
+}
+
+// FindByCodes retrieves the rejection templates with the given codes.
+func (r *RejectionReasonRepository) FindByCodes(codes []string) ([]models.RejectionReason, error) {
+	var reasons []models.RejectionReason
+	if len(codes) == 0 {
+		return reasons, nil
+	}
+	err := r.db.Where("code IN ?", codes).Find(&reasons).Error
+	return reasons, err
+}
+
+// List returns rejection templates ordered by code, optionally only active ones.
+func (r *RejectionReasonRepository) List(activeOnly bool) ([]models.RejectionReason, error) {
+	query := r.db.Order("code ASC")
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	var reasons []models.RejectionReason
+	err := query.Find(&reasons).Error
+	return reasons, err
+}
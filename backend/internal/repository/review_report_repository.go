@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReviewReportRepository persists readers' reports on reviews.
+type ReviewReportRepository struct {
+	db *gorm.DB
+}
+
+// NewReviewReportRepository constructs a review report repository.
+func NewReviewReportRepository(db *gorm.DB) *ReviewReportRepository {
+	return &ReviewReportRepository{db: db}
+}
+
+// WithTx returns a repository bound to the provided transaction handle.
+func (r *ReviewReportRepository) WithTx(tx *gorm.DB) *ReviewReportRepository {
+	return &ReviewReportRepository{db: tx}
+}
+
+// Create stores a new report.
+func (r *ReviewReportRepository) Create(report *models.ReviewReport) error {
+	return r.db.Create(report).Error
+}
+
+// Save persists changes to a report, leaving its review and reporter alone.
+func (r *ReviewReportRepository) Save(report *models.ReviewReport) error {
+	return r.db.Omit(clause.Associations).Save(report).Error
+}
+
+// FindByID returns a report with its review and reporter.
+func (r *ReviewReportRepository) FindByID(id uuid.UUID) (*models.ReviewReport, error) {
+	var report models.ReviewReport
+	if err := r.db.Preload("Review").Preload("Reporter").First(&report, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// Exists reports whether the user has already reported the review.
+func (r *ReviewReportRepository) Exists(reviewID, reporterID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.ReviewReport{}).Where("review_id = ? AND reporter_id = ?", reviewID, reporterID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// CountOpen counts the open reports on a review.
+func (r *ReviewReportRepository) CountOpen(reviewID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.ReviewReport{}).
+		Where("review_id = ? AND status = ?", reviewID, models.ReportStatusOpen).
+		Count(&count).Error
+	return count, err
+}
+
+// CloseOpen marks every open report on a review as resolved or dismissed and
+// returns the reports it closed.
+func (r *ReviewReportRepository) CloseOpen(reviewID uuid.UUID, status models.ReportStatus, resolverID *uuid.UUID, note string, at time.Time) ([]models.ReviewReport, error) {
+	var reports []models.ReviewReport
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("review_id = ? AND status = ?", reviewID, models.ReportStatusOpen).Find(&reports).Error; err != nil {
+			return err
+		}
+		if len(reports) == 0 {
+			return nil
+		}
+		ids := make([]uuid.UUID, len(reports))
+		for i := range reports {
+			ids[i] = reports[i].ID
+		}
+		return tx.Model(&models.ReviewReport{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"status":      status,
+				"resolver_id": resolverID,
+				"note":        note,
+				"resolved_at": at,
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range reports {
+		reports[i].Status = status
+		reports[i].ResolverID = resolverID
+		reports[i].Note = note
+		reports[i].ResolvedAt = &at
+	}
+	return reports, nil
+}
+
+// ReviewReportListOptions filters the report queue.
+type ReviewReportListOptions struct {
+	ReviewID *uuid.UUID
+	Status   models.ReportStatus
+	Reason   models.ReportReason
+	Limit    int
+	Offset   int
+}
+
+// ReviewReportListResult is a page of reports.
+type ReviewReportListResult struct {
+	Reports []models.ReviewReport
+	Total   int64
+}
+
+// List returns matching reports on reviews that are not in the trash, oldest
+// first so that the queue is worked through in order.
+func (r *ReviewReportRepository) List(opts ReviewReportListOptions) (ReviewReportListResult, error) {
+	base := r.db.Model(&models.ReviewReport{}).
+		Joins("JOIN reviews ON reviews.id = review_reports.review_id AND reviews.deleted_at IS NULL")
+	if opts.ReviewID != nil {
+		base = base.Where("review_reports.review_id = ?", opts.ReviewID)
+	}
+	if opts.Status != "" {
+		base = base.Where("review_reports.status = ?", opts.Status)
+	}
+	if opts.Reason != "" {
+		base = base.Where("review_reports.reason = ?", opts.Reason)
+	}
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return ReviewReportListResult{}, err
+	}
+
+	listQuery := base.Session(&gorm.Session{}).
+		Preload("Review").Preload("Reporter").
+		Order("review_reports.created_at ASC")
+	if opts.Limit > 0 {
+		listQuery = listQuery.Limit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		listQuery = listQuery.Offset(opts.Offset)
+	}
+
+	var reports []models.ReviewReport
+	if err := listQuery.Find(&reports).Error; err != nil {
+		return ReviewReportListResult{}, err
+	}
+	return ReviewReportListResult{Reports: reports, Total: total}, nil
+}
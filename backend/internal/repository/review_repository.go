@@ -3,10 +3,13 @@ package repository
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
 	"github.com/hdu-dp/backend/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ReviewRepository manages persistence for reviews and images.
@@ -19,15 +22,42 @@ func NewReviewRepository(db *gorm.DB) *ReviewRepository {
 	return &ReviewRepository{db: db}
 }
 
+// WithTx returns a repository bound to the provided transaction handle.
+func (r *ReviewRepository) WithTx(tx *gorm.DB) *ReviewRepository {
+	return &ReviewRepository{db: tx}
+}
+
+// Transaction runs fn inside a database transaction.
+func (r *ReviewRepository) Transaction(fn func(tx *gorm.DB) error) error {
+	return r.db.Transaction(fn)
+}
+
 // ListOptions holds query parameters for retrieving reviews.
 type ListOptions struct {
 	Statuses []models.ReviewStatus
-	AuthorID *uuid.UUID
-	Query    string
-	SortBy   string
-	SortDir  string
-	Limit    int
-	Offset   int
+	// WithPendingEdits also matches reviews that have an edit awaiting moderation.
+	WithPendingEdits bool
+	// PreloadEdits loads pending edits; leave unset for public listings.
+	PreloadEdits bool
+	// PreloadAppeals loads appeals; leave unset for public listings.
+	PreloadAppeals bool
+	AuthorID       *uuid.UUID
+	PlaceID        *uuid.UUID
+	MinRatings     map[models.RatingDimension]float32
+	// HideClaimedFor hides reviews under an unexpired claim by anyone other
+	// than the given administrator.
+	HideClaimedFor *uuid.UUID
+	// FlaggedOnly keeps reviews or pending edits with moderation flags.
+	FlaggedOnly bool
+	// DuplicatesOnly keeps reviews or pending edits resembling other reviews.
+	DuplicatesOnly bool
+	// PreloadAssessments loads pre-moderation assessments; admin listings only.
+	PreloadAssessments bool
+	Query              string
+	SortBy             string
+	SortDir            string
+	Limit              int
+	Offset             int
 }
 
 // ListResult represents a paginated resultset.
@@ -40,15 +70,35 @@ type ListResult struct {
 func (r *ReviewRepository) List(opts ListOptions) (ListResult, error) {
 	base := r.db.Model(&models.Review{})
 
-	if len(opts.Statuses) > 0 {
-		base = base.Where("status IN ?", opts.Statuses)
+	if len(opts.Statuses) > 0 && opts.WithPendingEdits {
+		base = base.Where("reviews.status IN ? OR EXISTS (SELECT 1 FROM review_edits WHERE review_edits.review_id = reviews.id)", opts.Statuses)
+	} else if len(opts.Statuses) > 0 {
+		base = base.Where("reviews.status IN ?", opts.Statuses)
 	}
 	if opts.AuthorID != nil {
-		base = base.Where("author_id = ?", opts.AuthorID)
+		base = base.Where("reviews.author_id = ?", opts.AuthorID)
+	}
+	if opts.PlaceID != nil {
+		base = base.Where("reviews.place_id = ?", opts.PlaceID)
+	}
+	if opts.HideClaimedFor != nil {
+		base = base.Where("reviews.claimed_by_id IS NULL OR reviews.claimed_by_id = ? OR reviews.claim_expires_at < ?", opts.HideClaimedFor, time.Now())
+	}
+	if opts.FlaggedOnly {
+		// Flags are stored as a JSON array; a non-empty one starts with `["`.
+		base = base.Where(`reviews.moderation_flags LIKE '["%' OR EXISTS (SELECT 1 FROM review_edits WHERE review_edits.review_id = reviews.id AND review_edits.moderation_flags LIKE '["%')`)
+	}
+	if opts.DuplicatesOnly {
+		base = base.Where(`reviews.duplicates LIKE '[{%' OR EXISTS (SELECT 1 FROM review_edits WHERE review_edits.review_id = reviews.id AND review_edits.duplicates LIKE '[{%')`)
+	}
+	for dim, min := range opts.MinRatings {
+		if column := dim.Column(); column != "" {
+			base = base.Where(fmt.Sprintf("reviews.%s >= ?", column), min)
+		}
 	}
 	if opts.Query != "" {
 		like := fmt.Sprintf("%%%s%%", opts.Query)
-		base = base.Where("title LIKE ? OR address LIKE ? OR description LIKE ?", like, like, like)
+		base = base.Where("reviews.title LIKE ? OR reviews.address LIKE ? OR reviews.description LIKE ?", like, like, like)
 	}
 
 	var total int64
@@ -56,14 +106,30 @@ func (r *ReviewRepository) List(opts ListOptions) (ListResult, error) {
 		return ListResult{}, err
 	}
 
-	listQuery := base.Session(&gorm.Session{}).Preload("Images").Preload("Author")
+	listQuery := base.Session(&gorm.Session{}).Preload("Images").Preload("Author").Preload("Place")
+	if opts.PreloadEdits {
+		listQuery = listQuery.Preload("PendingEdit")
+	}
+	if opts.PreloadAppeals {
+		listQuery = listQuery.Preload("Appeal")
+	}
+	if opts.PreloadAssessments {
+		listQuery = listQuery.Preload("Assessment")
+	}
 
-	sortBy := "created_at"
-	switch strings.ToLower(opts.SortBy) {
-	case "rating":
-		sortBy = "rating"
+	sortBy := "reviews.created_at"
+	switch sort := strings.ToLower(opts.SortBy); sort {
+	case "rating", "taste", "value", "service", "hygiene":
+		sortBy = "reviews." + models.RatingDimension(sort).Column()
+	case "score":
+		// Rank by the Bayesian score of the review's place.
+		listQuery = listQuery.Select("reviews.*").Joins("LEFT JOIN places ON places.id = reviews.place_id")
+		sortBy = "places.score"
+	case "helpful":
+		// Rank by the Wilson lower bound rather than raw vote counts.
+		sortBy = "reviews.helpful_score"
 	case "created_at":
-		sortBy = "created_at"
+		sortBy = "reviews.created_at"
 	}
 
 	sortDir := "DESC"
@@ -72,6 +138,9 @@ func (r *ReviewRepository) List(opts ListOptions) (ListResult, error) {
 	}
 
 	listQuery = listQuery.Order(fmt.Sprintf("%s %s", sortBy, sortDir))
+	if sortBy != "reviews.created_at" {
+		listQuery = listQuery.Order("reviews.created_at DESC")
+	}
 
 	if opts.Limit > 0 {
 		listQuery = listQuery.Limit(opts.Limit)
@@ -88,25 +157,257 @@ func (r *ReviewRepository) List(opts ListOptions) (ListResult, error) {
 	return ListResult{Reviews: reviews, Total: total}, nil
 }
 
+// FeedCursor marks the last review of a feed page; the next page starts
+// after it.
+type FeedCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// ListFeed returns up to limit approved reviews by the users followerID
+// follows, newest first, starting after the cursor if one is given. Followees
+// are matched in a subquery, so the cost does not grow with an IN list.
+func (r *ReviewRepository) ListFeed(followerID uuid.UUID, after *FeedCursor, limit int) ([]models.Review, error) {
+	followees := r.db.Model(&models.Follow{}).Select("followee_id").Where("follower_id = ?", followerID)
+	query := r.db.Model(&models.Review{}).
+		Where("reviews.status = ?", models.ReviewStatusApproved).
+		Where("reviews.author_id IN (?)", followees)
+	if after != nil {
+		query = query.Where("reviews.created_at < ? OR (reviews.created_at = ? AND reviews.id < ?)", after.CreatedAt, after.CreatedAt, after.ID)
+	}
+
+	var reviews []models.Review
+	err := query.Preload("Images").Preload("Author").Preload("Place").
+		Order("reviews.created_at DESC").Order("reviews.id DESC").
+		Limit(limit).
+		Find(&reviews).Error
+	return reviews, err
+}
+
 // Create inserts a new review.
 func (r *ReviewRepository) Create(review *models.Review) error {
 	return r.db.Create(review).Error
 }
 
-// Update persists changes to a review.
+// Update persists changes to a review if it is still at the version it was
+// read at, and bumps the version. It returns common.ErrVersionConflict when
+// another writer got there first. Associations are saved through their own
+// methods, claims only through Claim and ReleaseClaim and vote tallies only
+// through SetVoteStats.
 func (r *ReviewRepository) Update(review *models.Review) error {
-	return r.db.Save(review).Error
+	expected := review.Version
+	review.Version++
+	result := r.db.Model(review).
+		Select("*").
+		Omit(clause.Associations, "created_at", "claimed_by_id", "claim_expires_at", "helpful_count", "unhelpful_count", "helpful_score").
+		Where("version = ?", expected).
+		Updates(review)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = common.ErrVersionConflict
+	}
+	if result.Error != nil {
+		review.Version = expected
+		return result.Error
+	}
+	return nil
+}
+
+// Claim gives adminID the moderation lease on a review until the given time,
+// unless another administrator holds an unexpired lease. It reports whether
+// the claim was granted; the check and update are a single statement so
+// concurrent claims cannot both succeed.
+func (r *ReviewRepository) Claim(id, adminID uuid.UUID, until, now time.Time) (bool, error) {
+	result := r.db.Model(&models.Review{}).
+		Where("id = ? AND (claimed_by_id IS NULL OR claimed_by_id = ? OR claim_expires_at < ?)", id, adminID, now).
+		Updates(map[string]interface{}{"claimed_by_id": adminID, "claim_expires_at": until})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ReleaseClaim clears the moderation lease on a review.
+func (r *ReviewRepository) ReleaseClaim(id uuid.UUID) error {
+	return r.db.Model(&models.Review{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"claimed_by_id": nil, "claim_expires_at": nil}).Error
+}
+
+// SetVoteStats stores recounted vote tallies. It leaves the version and
+// update time alone, since votes do not change the review itself.
+func (r *ReviewRepository) SetVoteStats(reviewID uuid.UUID, stats models.ReviewVoteStats) error {
+	return r.db.Model(&models.Review{}).Where("id = ?", reviewID).UpdateColumns(map[string]interface{}{
+		"helpful_count":   stats.HelpfulCount,
+		"unhelpful_count": stats.UnhelpfulCount,
+		"helpful_score":   stats.HelpfulScore,
+	}).Error
+}
+
+// SaveEdit creates the pending edit of a review or replaces the one it was
+// read as. It returns common.ErrVersionConflict when that edit has been
+// approved, rejected or withdrawn in the meantime. Callers should bump the
+// review version in the same transaction.
+func (r *ReviewRepository) SaveEdit(edit *models.ReviewEdit) error {
+	if edit.CreatedAt.IsZero() {
+		return r.db.Create(edit).Error
+	}
+	result := r.db.Model(edit).
+		Select("*").
+		Omit("created_at").
+		Where("review_id = ?", edit.ReviewID).
+		Updates(edit)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = common.ErrVersionConflict
+	}
+	return result.Error
+}
+
+// DeleteEdit discards the pending edit of a review, if any.
+func (r *ReviewRepository) DeleteEdit(reviewID uuid.UUID) error {
+	return r.db.Where("review_id = ?", reviewID).Delete(&models.ReviewEdit{}).Error
 }
 
 // FindByID returns a review by UUID including relations.
 func (r *ReviewRepository) FindByID(id uuid.UUID) (*models.Review, error) {
 	var review models.Review
-	if err := r.db.Preload("Images").Preload("Author").First(&review, "id = ?", id).Error; err != nil {
+	if err := r.db.Preload("Images").Preload("Author").Preload("Place").Preload("PendingEdit").Preload("Appeal").Preload("Assessment").First(&review, "id = ?", id).Error; err != nil {
 		return nil, err
 	}
 	return &review, nil
 }
 
+// ListUnassigned returns reviews that are not yet linked to a place.
+func (r *ReviewRepository) ListUnassigned() ([]models.Review, error) {
+	var reviews []models.Review
+	if err := r.db.Where("place_id IS NULL").Order("created_at ASC").Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// AssignPlace links a review to a place without touching other columns.
+func (r *ReviewRepository) AssignPlace(reviewID, placeID uuid.UUID) error {
+	return r.db.Model(&models.Review{}).Where("id = ?", reviewID).Updates(map[string]interface{}{
+		"place_id": placeID,
+		"version":  gorm.Expr("version + 1"),
+	}).Error
+}
+
+// CreateRevision records a review revision.
+func (r *ReviewRepository) CreateRevision(revision *models.ReviewRevision) error {
+	return r.db.Create(revision).Error
+}
+
+// ListRevisions returns the revisions of a review, oldest first.
+func (r *ReviewRepository) ListRevisions(reviewID uuid.UUID) ([]models.ReviewRevision, error) {
+	var revisions []models.ReviewRevision
+	if err := r.db.Preload("Editor").Where("review_id = ?", reviewID).Order("created_at ASC").Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// LatestRevision returns the most recent revision of a review.
+func (r *ReviewRepository) LatestRevision(reviewID uuid.UUID) (*models.ReviewRevision, error) {
+	var revision models.ReviewRevision
+	if err := r.db.Where("review_id = ?", reviewID).Order("created_at DESC").First(&revision).Error; err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// CreateAppeal records a new appeal.
+func (r *ReviewRepository) CreateAppeal(appeal *models.ReviewAppeal) error {
+	return r.db.Create(appeal).Error
+}
+
+// SaveAppeal persists changes to an appeal.
+func (r *ReviewRepository) SaveAppeal(appeal *models.ReviewAppeal) error {
+	return r.db.Save(appeal).Error
+}
+
+// HasAppeal reports whether a review has ever been appealed.
+func (r *ReviewRepository) HasAppeal(reviewID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.ReviewAppeal{}).Where("review_id = ?", reviewID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// SaveAssessment stores the latest pre-moderation assessment of a review,
+// replacing any earlier one.
+func (r *ReviewRepository) SaveAssessment(assessment *models.ReviewAssessment) error {
+	return r.db.Save(assessment).Error
+}
+
+// SaveFingerprint stores a review's similarity fingerprint, replacing any
+// earlier one.
+func (r *ReviewRepository) SaveFingerprint(fingerprint *models.ReviewFingerprint) error {
+	return r.db.Save(fingerprint).Error
+}
+
+// FingerprintMatch is a stored fingerprint together with the review it
+// belongs to.
+type FingerprintMatch struct {
+	models.ReviewFingerprint
+	Title  string
+	Status models.ReviewStatus
+}
+
+// SimilarityCandidates returns fingerprints of live reviews other than
+// reviewID that may resemble the given one: every fingerprint by authorID or
+// with the same digest, plus the fingerprints of the last recent reviews
+// updated by other authors.
+func (r *ReviewRepository) SimilarityCandidates(reviewID, authorID uuid.UUID, digest string, recent int) ([]FingerprintMatch, error) {
+	query := func() *gorm.DB {
+		return r.db.Table("review_fingerprints").
+			Select("review_fingerprints.*, reviews.title, reviews.status").
+			Joins("JOIN reviews ON reviews.id = review_fingerprints.review_id AND reviews.deleted_at IS NULL").
+			Where("review_fingerprints.review_id <> ?", reviewID)
+	}
+
+	var matches []FingerprintMatch
+	if err := query().
+		Where("review_fingerprints.author_id = ? OR review_fingerprints.digest = ?", authorID, digest).
+		Scan(&matches).Error; err != nil {
+		return nil, err
+	}
+	if recent <= 0 {
+		return matches, nil
+	}
+
+	var others []FingerprintMatch
+	if err := query().
+		Where("review_fingerprints.author_id <> ? AND review_fingerprints.digest <> ?", authorID, digest).
+		Order("review_fingerprints.updated_at DESC").Limit(recent).
+		Scan(&others).Error; err != nil {
+		return nil, err
+	}
+	return append(matches, others...), nil
+}
+
+// AuthorRecord counts the author's approved and rejected reviews other than
+// exclude.
+func (r *ReviewRepository) AuthorRecord(authorID, exclude uuid.UUID) (approved, rejected int64, err error) {
+	var rows []struct {
+		Status models.ReviewStatus
+		Count  int64
+	}
+	err = r.db.Model(&models.Review{}).
+		Select("status, COUNT(*) AS count").
+		Where("author_id = ? AND id <> ? AND status IN ?", authorID, exclude,
+			[]models.ReviewStatus{models.ReviewStatusApproved, models.ReviewStatusRejected}).
+		Group("status").Scan(&rows).Error
+	for _, row := range rows {
+		if row.Status == models.ReviewStatusApproved {
+			approved = row.Count
+		} else {
+			rejected = row.Count
+		}
+	}
+	return approved, rejected, err
+}
+
 // AddImage appends a review image entry.
 func (r *ReviewRepository) AddImage(image *models.ReviewImage) error {
 	return r.db.Create(image).Error
@@ -117,13 +418,124 @@ func (r *ReviewRepository) DeleteImage(id uuid.UUID) error {
 	return r.db.Delete(&models.ReviewImage{}, "id = ?", id).Error
 }
 
-// Delete removes a review and associated images inside a transaction.
-func (r *ReviewRepository) Delete(id uuid.UUID) error {
+// SoftDelete moves a review to the trash, recording who removed it and why.
+// Like Update it fails with common.ErrVersionConflict if the review changed
+// since it was read.
+func (r *ReviewRepository) SoftDelete(review *models.Review) error {
+	result := r.db.Model(review).Where("version = ?", review.Version).Updates(map[string]interface{}{
+		"deleted_at":      review.DeletedAt,
+		"deleted_by_id":   review.DeletedByID,
+		"deletion_reason": review.DeletionReason,
+		"version":         gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrVersionConflict
+	}
+	review.Version++
+	return nil
+}
+
+// Restore takes a review out of the trash.
+func (r *ReviewRepository) Restore(id uuid.UUID) error {
+	return r.db.Unscoped().Model(&models.Review{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"deleted_at":      nil,
+		"deleted_by_id":   nil,
+		"deletion_reason": "",
+		"version":         gorm.Expr("version + 1"),
+	}).Error
+}
+
+// FindDeletedByID returns a trashed review by UUID including relations.
+func (r *ReviewRepository) FindDeletedByID(id uuid.UUID) (*models.Review, error) {
+	var review models.Review
+	if err := r.db.Unscoped().Preload("Images").Preload("Author").Preload("Place").
+		Where("deleted_at IS NOT NULL").First(&review, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// ListDeleted returns trashed reviews, most recently deleted first.
+func (r *ReviewRepository) ListDeleted(opts ListOptions) (ListResult, error) {
+	base := r.db.Unscoped().Model(&models.Review{}).Where("deleted_at IS NOT NULL")
+	if opts.Query != "" {
+		like := fmt.Sprintf("%%%s%%", opts.Query)
+		base = base.Where("title LIKE ? OR address LIKE ? OR description LIKE ?", like, like, like)
+	}
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return ListResult{}, err
+	}
+
+	listQuery := base.Session(&gorm.Session{}).Preload("Images").Preload("Author").Preload("Place").Order("deleted_at DESC")
+	if opts.Limit > 0 {
+		listQuery = listQuery.Limit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		listQuery = listQuery.Offset(opts.Offset)
+	}
+
+	var reviews []models.Review
+	if err := listQuery.Find(&reviews).Error; err != nil {
+		return ListResult{}, err
+	}
+	return ListResult{Reviews: reviews, Total: total}, nil
+}
+
+// ListDeletedBefore returns trashed reviews deleted before the cutoff along
+// with their images.
+func (r *ReviewRepository) ListDeletedBefore(cutoff time.Time) ([]models.Review, error) {
+	var reviews []models.Review
+	if err := r.db.Unscoped().Preload("Images").
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Order("deleted_at ASC").Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// Purge permanently removes a review and its images, edits and revisions
+// inside a transaction.
+func (r *ReviewRepository) Purge(id uuid.UUID) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Where("review_id = ?", id).Delete(&models.ReviewImage{}).Error; err != nil {
 			return err
 		}
-		if err := tx.Delete(&models.Review{}, "id = ?", id).Error; err != nil {
+		if err := tx.Where("review_id = ?", id).Delete(&models.ReviewEdit{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("review_id = ?", id).Delete(&models.ReviewRevision{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("review_id = ?", id).Delete(&models.ReviewAppeal{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("review_id = ?", id).Delete(&models.ReviewReport{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("review_id = ?", id).Delete(&models.ReviewFingerprint{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("review_id = ?", id).Delete(&models.ReviewAssessment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("review_id = ?", id).Delete(&models.Comment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("review_id = ?", id).Delete(&models.ReviewVote{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("review_id = ?", id).Delete(&models.Favorite{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("review_id = ?", id).Delete(&models.Notification{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Delete(&models.Review{}, "id = ?", id).Error; err != nil {
 			return err
 		}
 		return nil
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// ReviewVoteRepository persists readers' helpfulness votes.
+type ReviewVoteRepository struct {
+	db *gorm.DB
+}
+
+// NewReviewVoteRepository constructs a vote repository.
+func NewReviewVoteRepository(db *gorm.DB) *ReviewVoteRepository {
+	return &ReviewVoteRepository{db: db}
+}
+
+// WithTx returns a repository bound to the provided transaction handle.
+func (r *ReviewVoteRepository) WithTx(tx *gorm.DB) *ReviewVoteRepository {
+	return &ReviewVoteRepository{db: tx}
+}
+
+// Find returns the user's vote on a review, or nil if there is none.
+func (r *ReviewVoteRepository) Find(reviewID, userID uuid.UUID) (*models.ReviewVote, error) {
+	var vote models.ReviewVote
+	err := r.db.Where("review_id = ? AND user_id = ?", reviewID, userID).First(&vote).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &vote, nil
+}
+
+// Save creates or updates a vote.
+func (r *ReviewVoteRepository) Save(vote *models.ReviewVote) error {
+	return r.db.Save(vote).Error
+}
+
+// Delete removes the user's vote on a review, if any.
+func (r *ReviewVoteRepository) Delete(reviewID, userID uuid.UUID) error {
+	return r.db.Where("review_id = ? AND user_id = ?", reviewID, userID).Delete(&models.ReviewVote{}).Error
+}
+
+// Tally counts the helpful and unhelpful votes on a review.
+func (r *ReviewVoteRepository) Tally(reviewID uuid.UUID) (helpful, unhelpful int64, err error) {
+	var rows []struct {
+		Helpful bool
+		Count   int64
+	}
+	err = r.db.Model(&models.ReviewVote{}).
+		Select("helpful, COUNT(*) AS count").
+		Where("review_id = ?", reviewID).
+		Group("helpful").
+		Scan(&rows).Error
+	for _, row := range rows {
+		if row.Helpful {
+			helpful = row.Count
+		} else {
+			unhelpful = row.Count
+		}
+	}
+	return helpful, unhelpful, err
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// SensitiveWordRepository manages persistence for the sensitive word list.
+type SensitiveWordRepository struct {
+	db *gorm.DB
+}
+
+// NewSensitiveWordRepository constructs a sensitive word repository.
+func NewSensitiveWordRepository(db *gorm.DB) *SensitiveWordRepository {
+	return &SensitiveWordRepository{db: db}
+}
+
+// SensitiveWordListOptions holds query parameters for listing words.
+type SensitiveWordListOptions struct {
+	Category string
+	Action   models.SensitiveWordAction
+	Query    string
+	Limit    int
+	Offset   int
+}
+
+// SensitiveWordListResult represents a paginated word resultset.
+type SensitiveWordListResult struct {
+	Words []models.SensitiveWord
+	Total int64
+}
+
+// Create inserts a new word.
+func (r *SensitiveWordRepository) Create(word *models.SensitiveWord) error {
+	return r.db.Create(word).Error
+}
+
+// Update persists changes to a word.
+func (r *SensitiveWordRepository) Update(word *models.SensitiveWord) error {
+	return r.db.Save(word).Error
+}
+
+// Delete removes a word.
+func (r *SensitiveWordRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.SensitiveWord{}, "id = ?", id).Error
+}
+
+// FindByID retrieves a word by ID.
+func (r *SensitiveWordRepository) FindByID(id uuid.UUID) (*models.SensitiveWord, error) {
+	var word models.SensitiveWord
+	if err := r.db.First(&word, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &word, nil
+}
+
+// FindByWord retrieves an entry by its word.
+func (r *SensitiveWordRepository) FindByWord(text string) (*models.SensitiveWord, error) {
+	var word models.SensitiveWord
+	if err := r.db.First(&word, "word = ?", text).Error; err != nil {
+		return nil, err
+	}
+	return &word, nil
+}
+
+// All returns the complete word list.
+func (r *SensitiveWordRepository) All() ([]models.SensitiveWord, error) {
+	var words []models.SensitiveWord
+	err := r.db.Order("word ASC").Find(&words).Error
+	return words, err
+}
+
+// List fetches words using provided options, ordered by word.
+func (r *SensitiveWordRepository) List(opts SensitiveWordListOptions) (SensitiveWordListResult, error) {
+	base := r.db.Model(&models.SensitiveWord{})
+	if opts.Category != "" {
+		base = base.Where("category = ?", opts.Category)
+	}
+	if opts.Action != "" {
+		base = base.Where("action = ?", opts.Action)
+	}
+	if opts.Query != "" {
+		like := fmt.Sprintf("%%%s%%", opts.Query)
+		base = base.Where("word LIKE ? OR variants LIKE ?", like, like)
+	}
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return SensitiveWordListResult{}, err
+	}
+
+	var words []models.SensitiveWord
+	if err := base.Order("word ASC").Limit(opts.Limit).Offset(opts.Offset).Find(&words).Error; err != nil {
+		return SensitiveWordListResult{}, err
+	}
+	return SensitiveWordListResult{Words: words, Total: total}, nil
+}
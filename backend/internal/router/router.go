@@ -13,13 +13,26 @@ import (
 
 // Params groups dependencies required for routing.
 type Params struct {
-	Engine          *gin.Engine
-	AuthMiddleware  *middleware.AuthMiddleware
-	AuthHandler     *handlers.AuthHandler
-	UserHandler     *handlers.UserHandler
-	ReviewHandler   *handlers.ReviewHandler
-	AdminHandler    *adminHandlers.ReviewAdminHandler
-	StaticUploadDir string
+	Engine                      *gin.Engine
+	AuthMiddleware              *middleware.AuthMiddleware
+	AuthHandler                 *handlers.AuthHandler
+	UserHandler                 *handlers.UserHandler
+	ReviewHandler               *handlers.ReviewHandler
+	PlaceHandler                *handlers.PlaceHandler
+	ReportHandler               *handlers.ReportHandler
+	CommentHandler              *handlers.CommentHandler
+	VoteHandler                 *handlers.VoteHandler
+	FavoriteHandler             *handlers.FavoriteHandler
+	FollowHandler               *handlers.FollowHandler
+	NotificationHandler         *handlers.NotificationHandler
+	StreamHandler               *handlers.StreamHandler
+	AdminHandler                *adminHandlers.ReviewAdminHandler
+	AdminPlaceHandler           *adminHandlers.PlaceAdminHandler
+	AdminRejectionReasonHandler *adminHandlers.RejectionReasonAdminHandler
+	AdminSensitiveWordHandler   *adminHandlers.SensitiveWordAdminHandler
+	AdminReportHandler          *adminHandlers.ReportAdminHandler
+	AdminCommentHandler         *adminHandlers.CommentAdminHandler
+	StaticUploadDir             string
 }
 
 // Register configures API routes on the provided engine.
@@ -45,26 +58,101 @@ func Register(p Params) {
 		})
 	}
 
-	api.GET("/reviews", p.ReviewHandler.ListPublic)
+	// Public reads take optional auth so that signed-in viewers see which
+	// reviews and places they saved.
+	api.GET("/reviews", p.AuthMiddleware.OptionalAuth(), p.ReviewHandler.ListPublic)
 	// Detail endpoint should be accessible to authed/unauthed; optional auth ensures role-based access when provided.
 	api.GET("/reviews/:id", p.AuthMiddleware.OptionalAuth(), p.ReviewHandler.Detail)
+	api.GET("/reviews/:id/comments", p.CommentHandler.List)
+	api.GET("/places", p.AuthMiddleware.OptionalAuth(), p.PlaceHandler.List)
+	api.GET("/places/:id", p.AuthMiddleware.OptionalAuth(), p.PlaceHandler.Detail)
+	api.GET("/places/:id/reviews", p.AuthMiddleware.OptionalAuth(), p.PlaceHandler.Reviews)
 
 	protected := api.Group("")
 	protected.Use(p.AuthMiddleware.RequireAuth())
 	{
 		protected.GET("/users/me", p.UserHandler.Me)
+		protected.GET("/favorites", p.FavoriteHandler.List)
+		protected.PUT("/users/:id/follow", p.FollowHandler.Follow)
+		protected.DELETE("/users/:id/follow", p.FollowHandler.Unfollow)
+		protected.GET("/users/:id/followers", p.FollowHandler.Followers)
+		protected.GET("/users/:id/following", p.FollowHandler.Following)
+		protected.GET("/feed", p.FollowHandler.Feed)
+		protected.GET("/notifications", p.NotificationHandler.List)
+		protected.GET("/notifications/unread-count", p.NotificationHandler.UnreadCount)
+		protected.PUT("/notifications/read-all", p.NotificationHandler.MarkAllRead)
+		protected.PUT("/notifications/:id/read", p.NotificationHandler.MarkRead)
+		protected.GET("/events", p.StreamHandler.Stream)
 
 		protected.POST("/reviews", p.ReviewHandler.Submit)
 		protected.GET("/reviews/me", p.ReviewHandler.MyReviews)
+		protected.PUT("/reviews/:id", p.ReviewHandler.Update)
+		protected.DELETE("/reviews/:id", p.ReviewHandler.Delete)
+		protected.POST("/reviews/:id/submit", p.ReviewHandler.SubmitDraft)
+		protected.POST("/reviews/:id/withdraw", p.ReviewHandler.Withdraw)
+		protected.POST("/reviews/:id/appeal", p.ReviewHandler.Appeal)
+		protected.GET("/reviews/:id/revisions", p.ReviewHandler.Revisions)
 		protected.POST("/reviews/:id/images", p.ReviewHandler.UploadImage)
+		protected.POST("/reviews/:id/reports", p.ReportHandler.Create)
+		protected.GET("/comments/me", p.CommentHandler.MyComments)
+		protected.POST("/reviews/:id/comments", p.CommentHandler.Create)
+		protected.PUT("/reviews/:id/comments/:comment_id", p.CommentHandler.Update)
+		protected.DELETE("/reviews/:id/comments/:comment_id", p.CommentHandler.Delete)
+		protected.PUT("/reviews/:id/vote", p.VoteHandler.Vote)
+		protected.DELETE("/reviews/:id/vote", p.VoteHandler.Unvote)
+		protected.PUT("/reviews/:id/favorite", p.FavoriteHandler.FavoriteReview)
+		protected.DELETE("/reviews/:id/favorite", p.FavoriteHandler.UnfavoriteReview)
+
+		protected.POST("/places", p.PlaceHandler.Create)
+		protected.PUT("/places/:id/favorite", p.FavoriteHandler.FavoritePlace)
+		protected.DELETE("/places/:id/favorite", p.FavoriteHandler.UnfavoritePlace)
 	}
 
 	admin := api.Group("/admin")
 	admin.Use(p.AuthMiddleware.RequireAuth(), p.AuthMiddleware.RequireRoles("admin"))
 	{
 		admin.GET("/reviews/pending", p.AdminHandler.Pending)
+		admin.GET("/reviews/trash", p.AdminHandler.Trash)
+		admin.GET("/reviews/appealed", p.AdminHandler.Appealed)
+		admin.POST("/reviews/bulk/approve", p.AdminHandler.BulkApprove)
+		admin.POST("/reviews/bulk/reject", p.AdminHandler.BulkReject)
+		admin.POST("/reviews/bulk/delete", p.AdminHandler.BulkDelete)
+		admin.GET("/reviews/:id/diff", p.AdminHandler.Diff)
+		admin.POST("/reviews/:id/claim", p.AdminHandler.Claim)
+		admin.DELETE("/reviews/:id/claim", p.AdminHandler.ReleaseClaim)
 		admin.PUT("/reviews/:id/approve", p.AdminHandler.Approve)
 		admin.PUT("/reviews/:id/reject", p.AdminHandler.Reject)
+		admin.PUT("/reviews/:id/appeal/accept", p.AdminHandler.AcceptAppeal)
+		admin.PUT("/reviews/:id/appeal/deny", p.AdminHandler.DenyAppeal)
 		admin.DELETE("/reviews/:id", p.AdminHandler.Delete)
+		admin.POST("/reviews/:id/restore", p.AdminHandler.Restore)
+
+		admin.GET("/moderation-actions", p.AdminHandler.ModerationActions)
+
+		admin.GET("/rejection-reasons", p.AdminRejectionReasonHandler.List)
+		admin.POST("/rejection-reasons", p.AdminRejectionReasonHandler.Create)
+		admin.PUT("/rejection-reasons/:id", p.AdminRejectionReasonHandler.Update)
+		admin.DELETE("/rejection-reasons/:id", p.AdminRejectionReasonHandler.Delete)
+
+		admin.GET("/sensitive-words", p.AdminSensitiveWordHandler.List)
+		admin.POST("/sensitive-words", p.AdminSensitiveWordHandler.Create)
+		admin.POST("/sensitive-words/test", p.AdminSensitiveWordHandler.Test)
+		admin.PUT("/sensitive-words/:id", p.AdminSensitiveWordHandler.Update)
+		admin.DELETE("/sensitive-words/:id", p.AdminSensitiveWordHandler.Delete)
+
+		admin.GET("/reports", p.AdminReportHandler.List)
+		admin.PUT("/reports/:id/resolve", p.AdminReportHandler.Resolve)
+		admin.PUT("/reports/:id/dismiss", p.AdminReportHandler.Dismiss)
+
+		admin.GET("/comments/pending", p.AdminCommentHandler.Pending)
+		admin.PUT("/comments/:id/approve", p.AdminCommentHandler.Approve)
+		admin.PUT("/comments/:id/reject", p.AdminCommentHandler.Reject)
+		admin.DELETE("/comments/:id", p.AdminCommentHandler.Delete)
+
+		admin.POST("/places/migrate", p.AdminPlaceHandler.MigrateReviews)
+		admin.PUT("/places/:id", p.AdminPlaceHandler.Update)
+		admin.PUT("/places/:id/confirm", p.AdminPlaceHandler.Confirm)
+		admin.POST("/places/:id/recompute", p.AdminPlaceHandler.RecomputeStats)
+		admin.DELETE("/places/:id", p.AdminPlaceHandler.Delete)
 	}
 }
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
+	"github.com/hdu-dp/backend/internal/models"
+	"github.com/hdu-dp/backend/internal/moderation"
+	"github.com/hdu-dp/backend/internal/repository"
+	"github.com/hdu-dp/backend/internal/textfilter"
+	"gorm.io/gorm"
+)
+
+// maxCommentBody bounds the length of a comment, in characters.
+const maxCommentBody = 1000
+
+// CommentService handles comments on published reviews and their moderation.
+type CommentService struct {
+	comments *repository.CommentRepository
+	filter   *textfilter.Filter
+	// pipeline pre-moderates new and edited comments under its own policy;
+	// nil queues them all for an administrator.
+	pipeline      *moderation.Pipeline
+	notifications *NotificationService
+}
+
+// NewCommentService constructs a comment service instance. Comments are
+// screened by filter and assessed by pipeline like reviews are, and
+// notifications announces them once published.
+func NewCommentService(comments *repository.CommentRepository, filter *textfilter.Filter, pipeline *moderation.Pipeline, notifications *NotificationService) *CommentService {
+	return &CommentService{comments: comments, filter: filter, pipeline: pipeline, notifications: notifications}
+}
+
+// CommentInput carries a new comment. ParentID makes it a reply.
+type CommentInput struct {
+	Body     string
+	ParentID *uuid.UUID
+}
+
+// CommentFilters selects comments from a listing.
+type CommentFilters struct {
+	Page     int
+	PageSize int
+	// FlaggedOnly keeps comments flagged by the sensitive-word filter.
+	FlaggedOnly bool
+	// Status keeps comments in the given state; empty keeps all.
+	Status models.CommentStatus
+}
+
+// CommentListResult wraps comments with pagination info.
+type CommentListResult struct {
+	Data       []models.Comment `json:"data"`
+	Pagination Pagination       `json:"pagination"`
+}
+
+// Create adds a comment to a published review. Replies to a reply are
+// attached to the top-level comment, keeping threads one level deep. The
+// comment is published straight away only if pre-moderation approves it.
+func (s *CommentService) Create(review *models.Review, authorID uuid.UUID, input CommentInput) (*models.Comment, error) {
+	if review.Status != models.ReviewStatusApproved {
+		return nil, common.ErrReviewNotCommentable
+	}
+	body, err := validateCommentBody(input.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &models.Comment{
+		ID:       uuid.New(),
+		ReviewID: review.ID,
+		AuthorID: authorID,
+		Body:     body,
+	}
+	if input.ParentID != nil {
+		parent, err := s.comments.FindByID(*input.ParentID)
+		if err != nil || parent.ReviewID != review.ID || parent.Status != models.CommentStatusApproved {
+			return nil, invalidComment("parent comment not found on this review")
+		}
+		parentID := parent.ID
+		if parent.ParentID != nil {
+			parentID = *parent.ParentID
+		}
+		comment.ParentID = &parentID
+	}
+
+	if err := s.moderate(comment); err != nil {
+		return nil, err
+	}
+	if err := s.comments.Create(comment); err != nil {
+		return nil, err
+	}
+	s.notifications.CommentPublished(comment)
+	return s.comments.FindByID(comment.ID)
+}
+
+// Update replaces the body of a comment, which then goes through moderation
+// again.
+func (s *CommentService) Update(comment *models.Comment, body string) error {
+	body, err := validateCommentBody(body)
+	if err != nil {
+		return err
+	}
+	comment.Body = body
+	if err := s.moderate(comment); err != nil {
+		return err
+	}
+	if err := s.comments.Update(comment); err != nil {
+		return err
+	}
+	s.notifications.CommentPublished(comment)
+	return nil
+}
+
+// Delete soft-deletes a comment and its replies.
+func (s *CommentService) Delete(comment *models.Comment) error {
+	return s.comments.Delete(comment)
+}
+
+// Get returns a comment by ID.
+func (s *CommentService) Get(id uuid.UUID) (*models.Comment, error) {
+	return s.comments.FindByID(id)
+}
+
+// GetOnReview returns a comment only if it belongs to the review.
+func (s *CommentService) GetOnReview(reviewID, id uuid.UUID) (*models.Comment, error) {
+	comment, err := s.comments.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrCommentNotFound
+		}
+		return nil, err
+	}
+	if comment.ReviewID != reviewID {
+		return nil, common.ErrCommentNotFound
+	}
+	return comment, nil
+}
+
+// ListByReview returns a page of the published top-level comments on a
+// review, oldest first, each with its published replies.
+func (s *CommentService) ListByReview(reviewID uuid.UUID, filters CommentFilters) (CommentListResult, error) {
+	return s.list(repository.CommentListOptions{
+		ReviewID: &reviewID,
+		Status:   models.CommentStatusApproved,
+		TopLevel: true,
+	}, filters)
+}
+
+// ListPending returns the comments awaiting moderation, oldest first.
+func (s *CommentService) ListPending(filters CommentFilters) (CommentListResult, error) {
+	return s.list(repository.CommentListOptions{
+		Status:      models.CommentStatusPending,
+		FlaggedOnly: filters.FlaggedOnly,
+	}, filters)
+}
+
+// ListByAuthor returns a page of the author's own comments in any state,
+// newest first, so pending and rejected ones stay visible to them.
+func (s *CommentService) ListByAuthor(authorID uuid.UUID, filters CommentFilters) (CommentListResult, error) {
+	return s.list(repository.CommentListOptions{
+		AuthorID:    &authorID,
+		Status:      filters.Status,
+		NewestFirst: true,
+	}, filters)
+}
+
+func (s *CommentService) list(opts repository.CommentListOptions, filters CommentFilters) (CommentListResult, error) {
+	limit, page, offset := normalizePage(filters.Page, filters.PageSize)
+	opts.Limit = limit
+	opts.Offset = offset
+	result, err := s.comments.List(opts)
+	if err != nil {
+		return CommentListResult{}, err
+	}
+
+	return CommentListResult{
+		Data:       result.Comments,
+		Pagination: newPagination(page, limit, result.Total),
+	}, nil
+}
+
+// Approve publishes a pending comment.
+func (s *CommentService) Approve(comment *models.Comment) error {
+	if comment.Status != models.CommentStatusPending {
+		return common.ErrCommentAlreadyProcessed
+	}
+	approveComment(comment)
+	if err := s.comments.Update(comment); err != nil {
+		return err
+	}
+	s.notifications.CommentPublished(comment)
+	return nil
+}
+
+// Reject refuses a pending comment, keeping the reason for its author.
+func (s *CommentService) Reject(comment *models.Comment, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return errors.New("reason is required")
+	}
+	if comment.Status != models.CommentStatusPending {
+		return common.ErrCommentAlreadyProcessed
+	}
+	rejectComment(comment, reason)
+	return s.comments.Update(comment)
+}
+
+// moderate screens a new or edited comment and, when the pipeline allows,
+// decides on it as the system. Otherwise the comment waits for an
+// administrator.
+func (s *CommentService) moderate(comment *models.Comment) error {
+	flags, err := screenFields(s.filter, &comment.Body)
+	if err != nil {
+		return err
+	}
+	comment.Status = models.CommentStatusPending
+	comment.RejectionReason = ""
+	comment.ModerationFlags = flags
+
+	if s.pipeline == nil {
+		return nil
+	}
+	result := s.pipeline.Assess(moderation.Subject{
+		ReviewID:    comment.ReviewID,
+		AuthorID:    comment.AuthorID,
+		Description: comment.Body,
+	})
+	switch result.Decision {
+	case models.RiskDecisionApprove:
+		approveComment(comment)
+	case models.RiskDecisionReject:
+		rejectComment(comment, autoRejectionReason(result.Findings))
+	}
+	return nil
+}
+
+func approveComment(comment *models.Comment) {
+	comment.Status = models.CommentStatusApproved
+	comment.RejectionReason = ""
+	comment.ModerationFlags = nil
+}
+
+func rejectComment(comment *models.Comment, reason string) {
+	comment.Status = models.CommentStatusRejected
+	comment.RejectionReason = reason
+	comment.ModerationFlags = nil
+}
+
+func validateCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", invalidComment("body is required")
+	}
+	if utf8.RuneCountInString(body) > maxCommentBody {
+		return "", invalidComment(fmt.Sprintf("body must be at most %d characters", maxCommentBody))
+	}
+	return body, nil
+}
+
+func invalidComment(msg string) error {
+	return validationError{kind: common.ErrInvalidComment, msg: msg}
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
+	"github.com/hdu-dp/backend/internal/models"
+	"github.com/hdu-dp/backend/internal/repository"
+)
+
+// FavoriteService manages the reviews and places users save for later.
+type FavoriteService struct {
+	favorites *repository.FavoriteRepository
+}
+
+// NewFavoriteService constructs a favorite service instance.
+func NewFavoriteService(favorites *repository.FavoriteRepository) *FavoriteService {
+	return &FavoriteService{favorites: favorites}
+}
+
+// FavoriteFilters selects a user's favorites.
+type FavoriteFilters struct {
+	Page     int
+	PageSize int
+	Type     models.FavoriteType
+}
+
+// FavoriteListResult wraps favorites with pagination info.
+type FavoriteListResult struct {
+	Data       []models.Favorite `json:"data"`
+	Pagination Pagination        `json:"pagination"`
+}
+
+// FavoriteReview saves a published review. Saving it again changes nothing.
+func (s *FavoriteService) FavoriteReview(userID uuid.UUID, review *models.Review) error {
+	if review.Status != models.ReviewStatusApproved {
+		return common.ErrReviewNotFavoritable
+	}
+	reviewID := review.ID
+	return s.favorites.Create(&models.Favorite{UserID: userID, Type: models.FavoriteTypeReview, ReviewID: &reviewID})
+}
+
+// UnfavoriteReview removes a review from the user's favorites, if saved.
+func (s *FavoriteService) UnfavoriteReview(userID, reviewID uuid.UUID) error {
+	return s.favorites.Delete(userID, models.FavoriteTypeReview, reviewID)
+}
+
+// FavoritePlace saves a place. Saving it again changes nothing.
+func (s *FavoriteService) FavoritePlace(userID uuid.UUID, place *models.Place) error {
+	placeID := place.ID
+	return s.favorites.Create(&models.Favorite{UserID: userID, Type: models.FavoriteTypePlace, PlaceID: &placeID})
+}
+
+// UnfavoritePlace removes a place from the user's favorites, if saved.
+func (s *FavoriteService) UnfavoritePlace(userID, placeID uuid.UUID) error {
+	return s.favorites.Delete(userID, models.FavoriteTypePlace, placeID)
+}
+
+// List returns a page of the user's favorites, most recently saved first.
+func (s *FavoriteService) List(userID uuid.UUID, filters FavoriteFilters) (FavoriteListResult, error) {
+	switch filters.Type {
+	case "", models.FavoriteTypeReview, models.FavoriteTypePlace:
+	default:
+		return FavoriteListResult{}, errors.New("type must be review or place")
+	}
+
+	limit, page, offset := normalizePage(filters.Page, filters.PageSize)
+	result, err := s.favorites.List(repository.FavoriteListOptions{
+		UserID: userID,
+		Type:   filters.Type,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return FavoriteListResult{}, err
+	}
+
+	favorited := true
+	for i := range result.Favorites {
+		if review := result.Favorites[i].Review; review != nil {
+			review.Favorited = &favorited
+		}
+		if place := result.Favorites[i].Place; place != nil {
+			place.Favorited = &favorited
+		}
+	}
+	return FavoriteListResult{
+		Data:       result.Favorites,
+		Pagination: newPagination(page, limit, result.Total),
+	}, nil
+}
+
+// MarkReviews sets Favorited on reviews for the given viewer; nil marks
+// nothing, leaving the flag out for anonymous viewers.
+func (s *FavoriteService) MarkReviews(viewerID *uuid.UUID, reviews []models.Review) error {
+	if viewerID == nil || len(reviews) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(reviews))
+	for i := range reviews {
+		ids[i] = reviews[i].ID
+	}
+	favorited, err := s.favorites.FavoritedIDs(*viewerID, models.FavoriteTypeReview, ids)
+	if err != nil {
+		return err
+	}
+	for i := range reviews {
+		saved := favorited[reviews[i].ID]
+		reviews[i].Favorited = &saved
+	}
+	return nil
+}
+
+// MarkReview sets Favorited on a single review for the given viewer.
+func (s *FavoriteService) MarkReview(viewerID *uuid.UUID, review *models.Review) error {
+	reviews := []models.Review{*review}
+	if err := s.MarkReviews(viewerID, reviews); err != nil {
+		return err
+	}
+	review.Favorited = reviews[0].Favorited
+	return nil
+}
+
+// MarkPlaces sets Favorited on places for the given viewer; nil marks
+// nothing, leaving the flag out for anonymous viewers.
+func (s *FavoriteService) MarkPlaces(viewerID *uuid.UUID, places []models.Place) error {
+	if viewerID == nil || len(places) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(places))
+	for i := range places {
+		ids[i] = places[i].ID
+	}
+	favorited, err := s.favorites.FavoritedIDs(*viewerID, models.FavoriteTypePlace, ids)
+	if err != nil {
+		return err
+	}
+	for i := range places {
+		saved := favorited[places[i].ID]
+		places[i].Favorited = &saved
+	}
+	return nil
+}
+
+// MarkPlace sets Favorited on a single place for the given viewer.
+func (s *FavoriteService) MarkPlace(viewerID *uuid.UUID, place *models.Place) error {
+	places := []models.Place{*place}
+	if err := s.MarkPlaces(viewerID, places); err != nil {
+		return err
+	}
+	place.Favorited = places[0].Favorited
+	return nil
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
+	"github.com/hdu-dp/backend/internal/models"
+	"github.com/hdu-dp/backend/internal/repository"
+)
+
+const (
+	// defaultFeedLimit is the feed page size when none is requested.
+	defaultFeedLimit = 20
+	// maxFeedLimit bounds the feed page size.
+	maxFeedLimit = 100
+)
+
+// FollowService manages the follow graph and the feed built from it.
+type FollowService struct {
+	follows       *repository.FollowRepository
+	users         *repository.UserRepository
+	reviews       *repository.ReviewRepository
+	notifications *NotificationService
+}
+
+// NewFollowService constructs a follow service instance. Users hear about new
+// followers through notifications.
+func NewFollowService(follows *repository.FollowRepository, users *repository.UserRepository, reviews *repository.ReviewRepository, notifications *NotificationService) *FollowService {
+	return &FollowService{follows: follows, users: users, reviews: reviews, notifications: notifications}
+}
+
+// FollowFilters paginates follower and following lists.
+type FollowFilters struct {
+	Page     int
+	PageSize int
+}
+
+// FollowListResult wraps follows with pagination info.
+type FollowListResult struct {
+	Data       []models.Follow `json:"data"`
+	Pagination Pagination      `json:"pagination"`
+}
+
+// FeedResult is a page of the feed. NextCursor fetches the following page and
+// is empty on the last one.
+type FeedResult struct {
+	Data       []models.Review `json:"data"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+// GetUser returns a user by ID.
+func (s *FollowService) GetUser(id uuid.UUID) (*models.User, error) {
+	return s.users.FindByID(id)
+}
+
+// Follow makes followerID follow followee. Following again changes nothing.
+func (s *FollowService) Follow(followerID uuid.UUID, followee *models.User) error {
+	if followerID == followee.ID {
+		return common.ErrSelfFollow
+	}
+	created, err := s.follows.Create(&models.Follow{FollowerID: followerID, FolloweeID: followee.ID})
+	if err != nil {
+		return err
+	}
+	if created {
+		s.notifications.Followed(followerID, followee.ID)
+	}
+	return nil
+}
+
+// Unfollow stops followerID following followeeID, if it did.
+func (s *FollowService) Unfollow(followerID, followeeID uuid.UUID) error {
+	return s.follows.Delete(followerID, followeeID)
+}
+
+// Followers returns a page of the user's followers, most recent first.
+func (s *FollowService) Followers(userID uuid.UUID, filters FollowFilters) (FollowListResult, error) {
+	limit, page, offset := normalizePage(filters.Page, filters.PageSize)
+	result, err := s.follows.ListFollowers(userID, limit, offset)
+	if err != nil {
+		return FollowListResult{}, err
+	}
+	return FollowListResult{Data: result.Follows, Pagination: newPagination(page, limit, result.Total)}, nil
+}
+
+// Following returns a page of the users the user follows, most recent first.
+func (s *FollowService) Following(userID uuid.UUID, filters FollowFilters) (FollowListResult, error) {
+	limit, page, offset := normalizePage(filters.Page, filters.PageSize)
+	result, err := s.follows.ListFollowing(userID, limit, offset)
+	if err != nil {
+		return FollowListResult{}, err
+	}
+	return FollowListResult{Data: result.Follows, Pagination: newPagination(page, limit, result.Total)}, nil
+}
+
+// Feed returns the approved reviews of the users userID follows, most
+// recently created first. Pages are keyed by the last review seen rather than
+// an offset, so reviews published while paging neither repeat nor go missing.
+func (s *FollowService) Feed(userID uuid.UUID, cursor string, limit int) (FeedResult, error) {
+	if limit <= 0 {
+		limit = defaultFeedLimit
+	}
+	limit = min(limit, maxFeedLimit)
+
+	var after *repository.FeedCursor
+	if cursor != "" {
+		decoded, err := decodeFeedCursor(cursor)
+		if err != nil {
+			return FeedResult{}, err
+		}
+		after = &decoded
+	}
+
+	// One extra review tells whether there is a next page.
+	reviews, err := s.reviews.ListFeed(userID, after, limit+1)
+	if err != nil {
+		return FeedResult{}, err
+	}
+	result := FeedResult{Data: reviews}
+	if len(reviews) > limit {
+		result.Data = reviews[:limit]
+		last := result.Data[limit-1]
+		result.NextCursor = encodeFeedCursor(repository.FeedCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+	return result, nil
+}
+
+// encodeFeedCursor packs a cursor into an opaque URL-safe token.
+func encodeFeedCursor(c repository.FeedCursor) string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + "_" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeFeedCursor(token string) (repository.FeedCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return repository.FeedCursor{}, common.ErrInvalidCursor
+	}
+	nanos, id, ok := strings.Cut(string(raw), "_")
+	if !ok {
+		return repository.FeedCursor{}, common.ErrInvalidCursor
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return repository.FeedCursor{}, common.ErrInvalidCursor
+	}
+	reviewID, err := uuid.Parse(id)
+	if err != nil {
+		return repository.FeedCursor{}, common.ErrInvalidCursor
+	}
+	return repository.FeedCursor{CreatedAt: time.Unix(0, n), ID: reviewID}, nil
+}
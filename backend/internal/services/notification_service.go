@@ -0,0 +1,236 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
+	"github.com/hdu-dp/backend/internal/models"
+	"github.com/hdu-dp/backend/internal/repository"
+	"github.com/hdu-dp/backend/internal/workflow"
+	"gorm.io/gorm"
+)
+
+// NotificationService records in-app notifications and serves them to their
+// recipients. Recording is best effort: failures are logged and never fail
+// the action that caused them.
+type NotificationService struct {
+	notifications *repository.NotificationRepository
+	reviews       *repository.ReviewRepository
+	comments      *repository.CommentRepository
+
+	mu        sync.RWMutex
+	listeners []NotificationListener
+}
+
+// NewNotificationService constructs a notification service instance.
+func NewNotificationService(notifications *repository.NotificationRepository, reviews *repository.ReviewRepository, comments *repository.CommentRepository) *NotificationService {
+	return &NotificationService{notifications: notifications, reviews: reviews, comments: comments}
+}
+
+// NotificationListener is told about notifications once they are recorded.
+// Listeners run synchronously and should return quickly.
+type NotificationListener func(models.Notification)
+
+// Subscribe registers a listener for recorded notifications.
+func (s *NotificationService) Subscribe(listener NotificationListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, listener)
+}
+
+// NotificationFilters selects notifications from a user's list.
+type NotificationFilters struct {
+	Page       int
+	PageSize   int
+	UnreadOnly bool
+}
+
+// NotificationListResult wraps notifications with pagination info.
+type NotificationListResult struct {
+	Data       []models.Notification `json:"data"`
+	Pagination Pagination            `json:"pagination"`
+}
+
+// reviewEventNotifications maps the review workflow actions authors hear
+// about to their notification types.
+var reviewEventNotifications = map[workflow.Action]models.NotificationType{
+	workflow.ActionApprove:      models.NotificationReviewApproved,
+	workflow.ActionReject:       models.NotificationReviewRejected,
+	workflow.ActionApproveEdit:  models.NotificationEditApproved,
+	workflow.ActionRejectEdit:   models.NotificationEditRejected,
+	workflow.ActionAcceptAppeal: models.NotificationAppealAccepted,
+	workflow.ActionDenyAppeal:   models.NotificationAppealDenied,
+	workflow.ActionRequeue:      models.NotificationReviewRequeued,
+	workflow.ActionDelete:       models.NotificationReviewDeleted,
+}
+
+// OnReviewEvent tells the author when someone else decides on their review.
+// The reason of the event, such as a rejection reason, becomes the message.
+// It is meant to be subscribed to the review workflow machine.
+func (s *NotificationService) OnReviewEvent(event workflow.Event) {
+	notificationType, ok := reviewEventNotifications[event.Action]
+	if !ok || event.Actor == workflow.ActorAuthor {
+		return
+	}
+	reviewID := event.ReviewID
+	s.notify(&models.Notification{
+		UserID:   event.AuthorID,
+		Type:     notificationType,
+		ActorID:  adminActor(event),
+		ReviewID: &reviewID,
+		Message:  event.Reason,
+	})
+}
+
+// CommentPublished tells the author of the review about a newly published
+// comment and, for a reply, the author of the comment replied to. Nobody is
+// notified of their own comment, nor twice about the same one when an edited
+// comment is published again.
+func (s *NotificationService) CommentPublished(comment *models.Comment) {
+	if comment.Status != models.CommentStatusApproved {
+		return
+	}
+	review, err := s.reviews.FindByID(comment.ReviewID)
+	if err != nil {
+		log.Printf("notify comment %s: load review: %v", comment.ID, err)
+		return
+	}
+	s.notifyComment(review.AuthorID, models.NotificationNewComment, comment)
+
+	if comment.ParentID == nil {
+		return
+	}
+	parent, err := s.comments.FindByID(*comment.ParentID)
+	if err != nil {
+		log.Printf("notify comment %s: load parent: %v", comment.ID, err)
+		return
+	}
+	if parent.AuthorID != review.AuthorID {
+		s.notifyComment(parent.AuthorID, models.NotificationCommentReply, comment)
+	}
+}
+
+func (s *NotificationService) notifyComment(recipientID uuid.UUID, notificationType models.NotificationType, comment *models.Comment) {
+	if recipientID == comment.AuthorID {
+		return
+	}
+	notified, err := s.notifications.ExistsForComment(recipientID, notificationType, comment.ID)
+	if err != nil {
+		log.Printf("notify comment %s: %v", comment.ID, err)
+		return
+	}
+	if notified {
+		return
+	}
+	actorID, reviewID, commentID := comment.AuthorID, comment.ReviewID, comment.ID
+	s.notify(&models.Notification{
+		UserID:    recipientID,
+		Type:      notificationType,
+		ActorID:   &actorID,
+		ReviewID:  &reviewID,
+		CommentID: &commentID,
+	})
+}
+
+// Followed tells a user about a new follower.
+func (s *NotificationService) Followed(followerID, followeeID uuid.UUID) {
+	s.notify(&models.Notification{
+		UserID:  followeeID,
+		Type:    models.NotificationNewFollower,
+		ActorID: &followerID,
+	})
+}
+
+// ReportClosed tells the reporter how their report was handled, with the
+// resolution note as the message.
+func (s *NotificationService) ReportClosed(report *models.ReviewReport) {
+	notificationType := models.NotificationReportResolved
+	if report.Status == models.ReportStatusDismissed {
+		notificationType = models.NotificationReportDismissed
+	}
+	reviewID, reportID := report.ReviewID, report.ID
+	s.notify(&models.Notification{
+		UserID:   report.ReporterID,
+		Type:     notificationType,
+		ActorID:  report.ResolverID,
+		ReviewID: &reviewID,
+		ReportID: &reportID,
+		Message:  report.Note,
+	})
+}
+
+func (s *NotificationService) notify(notification *models.Notification) {
+	if err := s.notifications.Create(notification); err != nil {
+		log.Printf("record %s notification for user %s: %v", notification.Type, notification.UserID, err)
+		return
+	}
+
+	s.mu.RLock()
+	listeners := append([]NotificationListener(nil), s.listeners...)
+	s.mu.RUnlock()
+	for _, listener := range listeners {
+		listener(*notification)
+	}
+}
+
+// List returns a page of the user's notifications, newest first.
+func (s *NotificationService) List(userID uuid.UUID, filters NotificationFilters) (NotificationListResult, error) {
+	limit, page, offset := normalizePage(filters.Page, filters.PageSize)
+	result, err := s.notifications.List(repository.NotificationListOptions{
+		UserID:     userID,
+		UnreadOnly: filters.UnreadOnly,
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		return NotificationListResult{}, err
+	}
+	return NotificationListResult{
+		Data:       result.Notifications,
+		Pagination: newPagination(page, limit, result.Total),
+	}, nil
+}
+
+// UnreadCount counts the user's unread notifications.
+func (s *NotificationService) UnreadCount(userID uuid.UUID) (int64, error) {
+	return s.notifications.CountUnread(userID)
+}
+
+// MarkRead marks one of the user's notifications read. Marking it again
+// changes nothing.
+func (s *NotificationService) MarkRead(userID, id uuid.UUID) (*models.Notification, error) {
+	notification, err := s.notifications.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotificationNotFound
+		}
+		return nil, err
+	}
+	if notification.UserID != userID {
+		return nil, common.ErrNotificationNotFound
+	}
+	if err := s.notifications.MarkRead(notification, time.Now()); err != nil {
+		return nil, err
+	}
+	return notification, nil
+}
+
+// MarkAllRead marks all of the user's notifications read and returns how
+// many were unread.
+func (s *NotificationService) MarkAllRead(userID uuid.UUID) (int64, error) {
+	return s.notifications.MarkAllRead(userID, time.Now())
+}
+
+// adminActor returns the administrator behind a review event, or nil when
+// the system acted.
+func adminActor(event workflow.Event) *uuid.UUID {
+	if event.Actor != workflow.ActorAdmin {
+		return nil
+	}
+	actorID := event.ActorID
+	return &actorID
+}
@@ -0,0 +1,344 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
+	"github.com/hdu-dp/backend/internal/models"
+	"github.com/hdu-dp/backend/internal/repository"
+	"github.com/hdu-dp/backend/internal/textfilter"
+	"gorm.io/gorm"
+)
+
+// placeMatchThreshold is the minimum similarity for a free-text address to be
+// considered the same place.
+const placeMatchThreshold = 0.8
+
+// PlaceService contains business logic around places and linking reviews to them.
+type PlaceService struct {
+	places  *repository.PlaceRepository
+	reviews *repository.ReviewRepository
+	prior   models.BayesianPrior
+}
+
+// NewPlaceService constructs a place service instance.
+func NewPlaceService(places *repository.PlaceRepository, reviews *repository.ReviewRepository, prior models.BayesianPrior) *PlaceService {
+	return &PlaceService{places: places, reviews: reviews, prior: prior}
+}
+
+// PlaceInput bundles editable place attributes.
+type PlaceInput struct {
+	Name       string
+	Address    string
+	CampusArea string
+	Category   string
+}
+
+// PlaceListFilters describes filters for place listings.
+type PlaceListFilters struct {
+	Page       int
+	PageSize   int
+	Query      string
+	CampusArea string
+	Category   string
+	Status     string
+	SortBy     string
+	SortDir    string
+}
+
+// PlaceListResult wraps place list responses with pagination info.
+type PlaceListResult struct {
+	Data       []models.Place `json:"data"`
+	Pagination Pagination     `json:"pagination"`
+}
+
+// PlaceMigrationResult summarises a run linking legacy reviews to places.
+type PlaceMigrationResult struct {
+	Scanned int `json:"scanned"`
+	Matched int `json:"matched"`
+	Created int `json:"created"`
+}
+
+// Create registers a new place. Places created by administrators are active
+// immediately; others wait for confirmation.
+func (s *PlaceService) Create(creatorID uuid.UUID, input PlaceInput, confirmed bool) (*models.Place, error) {
+	input, err := normalizePlaceInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	status := models.PlaceStatusPending
+	if confirmed {
+		status = models.PlaceStatusActive
+	}
+
+	place := &models.Place{
+		ID:          uuid.New(),
+		Name:        input.Name,
+		Address:     input.Address,
+		CampusArea:  input.CampusArea,
+		Category:    input.Category,
+		Status:      status,
+		CreatedByID: &creatorID,
+	}
+
+	if err := s.places.Create(place); err != nil {
+		return nil, err
+	}
+	return place, nil
+}
+
+// Update replaces the editable attributes of a place.
+func (s *PlaceService) Update(place *models.Place, input PlaceInput) error {
+	input, err := normalizePlaceInput(input)
+	if err != nil {
+		return err
+	}
+	place.Name = input.Name
+	place.Address = input.Address
+	place.CampusArea = input.CampusArea
+	place.Category = input.Category
+	return s.places.Update(place)
+}
+
+// Confirm marks a pending place as active.
+func (s *PlaceService) Confirm(place *models.Place) error {
+	place.Status = models.PlaceStatusActive
+	return s.places.Update(place)
+}
+
+// Delete removes a place; linked reviews keep their free-text address.
+func (s *PlaceService) Delete(place *models.Place) error {
+	return s.places.Delete(place.ID)
+}
+
+// RecomputeStats rebuilds the rating aggregates of a place from scratch.
+func (s *PlaceService) RecomputeStats(place *models.Place) error {
+	return recomputePlaceStats(s.places, s.prior, place)
+}
+
+// Get returns a place by ID.
+func (s *PlaceService) Get(id uuid.UUID) (*models.Place, error) {
+	return s.places.FindByID(id)
+}
+
+// List returns places matching the filters.
+func (s *PlaceService) List(filters PlaceListFilters) (PlaceListResult, error) {
+	limit, page, offset := normalizePage(filters.Page, filters.PageSize)
+
+	opts := repository.PlaceListOptions{
+		CampusArea: strings.TrimSpace(filters.CampusArea),
+		Category:   strings.TrimSpace(filters.Category),
+		Query:      filters.Query,
+		SortBy:     filters.SortBy,
+		SortDir:    filters.SortDir,
+		Limit:      limit,
+		Offset:     offset,
+	}
+	if filters.Status != "" {
+		opts.Statuses = []models.PlaceStatus{models.PlaceStatus(filters.Status)}
+	}
+
+	result, err := s.places.List(opts)
+	if err != nil {
+		return PlaceListResult{}, err
+	}
+
+	return PlaceListResult{
+		Data:       result.Places,
+		Pagination: newPagination(page, limit, result.Total),
+	}, nil
+}
+
+// MigrateReviews links reviews without a place to the best fuzzy match on
+// their free-text address, creating pending places for addresses that match
+// nothing. It is safe to run repeatedly.
+func (s *PlaceService) MigrateReviews() (PlaceMigrationResult, error) {
+	var result PlaceMigrationResult
+
+	err := s.reviews.Transaction(func(tx *gorm.DB) error {
+		places := s.places.WithTx(tx)
+		reviews := s.reviews.WithTx(tx)
+
+		unassigned, err := reviews.ListUnassigned()
+		if err != nil {
+			return err
+		}
+
+		touched := make(map[uuid.UUID]*models.Place)
+
+		for _, review := range unassigned {
+			result.Scanned++
+			if strings.TrimSpace(review.Address) == "" {
+				continue
+			}
+
+			place, err := matchPlace(places, review.Address)
+			if err != nil {
+				return err
+			}
+			if place == nil {
+				authorID := review.AuthorID
+				place = &models.Place{
+					ID:          uuid.New(),
+					Name:        strings.TrimSpace(review.Address),
+					Address:     strings.TrimSpace(review.Address),
+					Status:      models.PlaceStatusPending,
+					CreatedByID: &authorID,
+				}
+				if err := places.Create(place); err != nil {
+					return err
+				}
+				result.Created++
+			} else {
+				result.Matched++
+			}
+
+			if err := reviews.AssignPlace(review.ID, place.ID); err != nil {
+				return err
+			}
+			touched[place.ID] = place
+		}
+
+		for _, place := range touched {
+			if err := recomputePlaceStats(places, s.prior, place); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return PlaceMigrationResult{}, err
+	}
+	return result, nil
+}
+
+// resolvePlace finds the place a new review refers to: an explicit place ID
+// wins, otherwise the address is fuzzy-matched against known places and a
+// pending place is created when nothing matches.
+func resolvePlace(places *repository.PlaceRepository, authorID uuid.UUID, placeID *uuid.UUID, name, address string) (*models.Place, error) {
+	if placeID != nil {
+		place, err := places.FindByID(*placeID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, common.ErrPlaceNotFound
+			}
+			return nil, err
+		}
+		return place, nil
+	}
+
+	place, err := matchPlace(places, address)
+	if err != nil {
+		return nil, err
+	}
+	if place != nil {
+		return place, nil
+	}
+
+	if name == "" {
+		name = address
+	}
+	place = &models.Place{
+		ID:          uuid.New(),
+		Name:        name,
+		Address:     address,
+		Status:      models.PlaceStatusPending,
+		CreatedByID: &authorID,
+	}
+	if err := places.Create(place); err != nil {
+		return nil, err
+	}
+	return place, nil
+}
+
+func normalizePlaceInput(input PlaceInput) (PlaceInput, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	input.Address = strings.TrimSpace(input.Address)
+	input.CampusArea = strings.TrimSpace(input.CampusArea)
+	input.Category = strings.TrimSpace(input.Category)
+	if input.Name == "" || input.Address == "" {
+		return input, errors.New("name and address are required")
+	}
+	return input, nil
+}
+
+// matchPlace returns the known place whose address or name is most similar to
+// the given address, or nil when nothing reaches the match threshold. The
+// address is folded with textfilter.Canonical, so "学一食堂 ２楼" and
+// "学一食堂2楼" compare equal, and only the candidates the repository
+// narrows it down to are scored.
+func matchPlace(places *repository.PlaceRepository, address string) (*models.Place, error) {
+	target := textfilter.Canonical(address)
+	if target == "" {
+		return nil, nil
+	}
+	candidates, err := places.MatchCandidates(target)
+	if err != nil {
+		return nil, err
+	}
+
+	var best *models.Place
+	bestScore := 0.0
+	for i := range candidates {
+		for _, key := range []string{candidates[i].AddressKey, candidates[i].NameKey} {
+			score := addressSimilarity(target, key)
+			if score > bestScore {
+				best, bestScore = &candidates[i], score
+			}
+		}
+	}
+
+	if bestScore < placeMatchThreshold {
+		return nil, nil
+	}
+	return best, nil
+}
+
+// addressSimilarity scores two normalized addresses in [0, 1] using the
+// larger of a containment score and the Dice coefficient over rune bigrams.
+func addressSimilarity(a, b string) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	short, long := ra, rb
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+
+	containment := 0.0
+	if len(short) >= 4 && strings.Contains(string(long), string(short)) {
+		containment = 0.5 + 0.5*float64(len(short))/float64(len(long))
+	}
+
+	return max(containment, diceCoefficient(ra, rb))
+}
+
+func diceCoefficient(a, b []rune) float64 {
+	if len(a) < 2 || len(b) < 2 {
+		return 0
+	}
+
+	bigrams := make(map[[2]rune]int, len(a)-1)
+	for i := 0; i < len(a)-1; i++ {
+		bigrams[[2]rune{a[i], a[i+1]}]++
+	}
+
+	overlap := 0
+	for i := 0; i < len(b)-1; i++ {
+		key := [2]rune{b[i], b[i+1]}
+		if bigrams[key] > 0 {
+			bigrams[key]--
+			overlap++
+		}
+	}
+
+	return 2 * float64(overlap) / float64(len(a)-1+len(b)-1)
+}
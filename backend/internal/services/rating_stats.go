@@ -0,0 +1,67 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/models"
+	"github.com/hdu-dp/backend/internal/repository"
+)
+
+// ratingContribution captures how a review counts towards its place's
+// aggregates at a point in time. Taking one before and one after a mutation
+// lets a single helper handle status, rating and place changes alike.
+type ratingContribution struct {
+	placeID *uuid.UUID
+	rating  float32
+	counted bool
+}
+
+func contributionOf(review *models.Review) ratingContribution {
+	if review == nil {
+		return ratingContribution{}
+	}
+	return ratingContribution{
+		placeID: review.PlaceID,
+		rating:  review.Rating,
+		counted: review.PlaceID != nil && review.Status == models.ReviewStatusApproved,
+	}
+}
+
+func (c ratingContribution) equal(o ratingContribution) bool {
+	if c.counted != o.counted {
+		return false
+	}
+	if !c.counted {
+		return true
+	}
+	return *c.placeID == *o.placeID && c.rating == o.rating
+}
+
+// applyContribution moves a review's rating out of the aggregates described by
+// before and into those described by after. Each move is a single atomic
+// update of the place row within the caller's transaction.
+func applyContribution(places *repository.PlaceRepository, prior models.BayesianPrior, before, after ratingContribution) error {
+	if before.equal(after) {
+		return nil
+	}
+	if before.counted {
+		if err := places.AdjustStats(*before.placeID, before.rating, -1, prior); err != nil {
+			return err
+		}
+	}
+	if after.counted {
+		if err := places.AdjustStats(*after.placeID, after.rating, 1, prior); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recomputePlaceStats rebuilds a place's aggregates from its approved reviews.
+func recomputePlaceStats(places *repository.PlaceRepository, prior models.BayesianPrior, place *models.Place) error {
+	ratings, err := places.ApprovedRatings(place.ID)
+	if err != nil {
+		return err
+	}
+	place.Stats.Reset(ratings, prior)
+	return places.UpdateStats(place)
+}
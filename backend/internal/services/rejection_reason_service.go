@@ -0,0 +1,141 @@
+package services
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
+	"github.com/hdu-dp/backend/internal/models"
+	"github.com/hdu-dp/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// rejectionCodePattern restricts template codes to short lower-case slugs.
+var rejectionCodePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// RejectionReasonService manages the rejection templates administrators pick
+// from when rejecting reviews.
+type RejectionReasonService struct {
+	reasons *repository.RejectionReasonRepository
+}
+
+// NewRejectionReasonService constructs a rejection reason service instance.
+func NewRejectionReasonService(reasons *repository.RejectionReasonRepository) *RejectionReasonService {
+	return &RejectionReasonService{reasons: reasons}
+}
+
+// RejectionReasonInput bundles editable template attributes. A nil Active
+// defaults to true on creation and keeps the current value on update.
+type RejectionReasonInput struct {
+	Code         string
+	Title        string
+	Message      string
+	Translations map[string]string
+	Active       *bool
+}
+
+// List returns the templates ordered by code, optionally only active ones.
+func (s *RejectionReasonService) List(activeOnly bool) ([]models.RejectionReason, error) {
+	return s.reasons.List(activeOnly)
+}
+
+// Get returns a template by ID.
+func (s *RejectionReasonService) Get(id uuid.UUID) (*models.RejectionReason, error) {
+	return s.reasons.FindByID(id)
+}
+
+// Create registers a new template.
+func (s *RejectionReasonService) Create(input RejectionReasonInput) (*models.RejectionReason, error) {
+	input, err := normalizeRejectionReasonInput(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureCodeFree(input.Code, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	reason := &models.RejectionReason{
+		ID:           uuid.New(),
+		Code:         input.Code,
+		Title:        input.Title,
+		Message:      input.Message,
+		Translations: input.Translations,
+		Active:       input.Active == nil || *input.Active,
+	}
+	if err := s.reasons.Create(reason); err != nil {
+		return nil, err
+	}
+	return reason, nil
+}
+
+// Update replaces the attributes of a template. Reviews already rejected with
+// the old code keep it, so renaming a code detaches them from the template.
+func (s *RejectionReasonService) Update(reason *models.RejectionReason, input RejectionReasonInput) error {
+	input, err := normalizeRejectionReasonInput(input)
+	if err != nil {
+		return err
+	}
+	if err := s.ensureCodeFree(input.Code, reason.ID); err != nil {
+		return err
+	}
+
+	reason.Code = input.Code
+	reason.Title = input.Title
+	reason.Message = input.Message
+	reason.Translations = input.Translations
+	if input.Active != nil {
+		reason.Active = *input.Active
+	}
+	return s.reasons.Update(reason)
+}
+
+// Delete removes a template. Reviews rejected with it keep their code and
+// reason text; deactivating the template instead keeps their messages
+// localized.
+func (s *RejectionReasonService) Delete(reason *models.RejectionReason) error {
+	return s.reasons.Delete(reason.ID)
+}
+
+func (s *RejectionReasonService) ensureCodeFree(code string, self uuid.UUID) error {
+	existing, err := s.reasons.FindByCode(code)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID != self {
+		return common.ErrRejectionCodeTaken
+	}
+	return nil
+}
+
+func normalizeRejectionReasonInput(input RejectionReasonInput) (RejectionReasonInput, error) {
+	input.Code = normalizeRejectionCode(input.Code)
+	input.Title = strings.TrimSpace(input.Title)
+	input.Message = strings.TrimSpace(input.Message)
+	if !rejectionCodePattern.MatchString(input.Code) {
+		return input, errors.New("code must be 1-64 lower-case letters, digits, '-' or '_'")
+	}
+	if input.Title == "" || input.Message == "" {
+		return input, errors.New("title and message are required")
+	}
+
+	translations := make(map[string]string, len(input.Translations))
+	for lang, msg := range input.Translations {
+		lang = strings.ToLower(strings.TrimSpace(lang))
+		msg = strings.TrimSpace(msg)
+		if lang == "" || msg == "" {
+			continue
+		}
+		translations[lang] = msg
+	}
+	input.Translations = translations
+	return input, nil
+}
+
+func normalizeRejectionCode(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
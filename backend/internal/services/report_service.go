@@ -0,0 +1,206 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
+	"github.com/hdu-dp/backend/internal/models"
+	"github.com/hdu-dp/backend/internal/repository"
+	"github.com/hdu-dp/backend/internal/workflow"
+)
+
+// maxReportDetails bounds the free-text part of a report, in characters.
+const maxReportDetails = 500
+
+// ReportService handles readers' reports on published reviews.
+type ReportService struct {
+	reports *repository.ReviewReportRepository
+	reviews *ReviewService
+	// threshold is the number of open reports that sends a review back to
+	// moderation; 0 disables requeueing.
+	threshold     int
+	notifications *NotificationService
+}
+
+// NewReportService constructs a report service instance. Reviews reaching
+// threshold open reports are requeued through reviews, and reporters hear
+// through notifications how their reports were handled.
+func NewReportService(reports *repository.ReviewReportRepository, reviews *ReviewService, threshold int, notifications *NotificationService) *ReportService {
+	return &ReportService{reports: reports, reviews: reviews, threshold: threshold, notifications: notifications}
+}
+
+// ReportInput carries a reader's complaint.
+type ReportInput struct {
+	Reason  models.ReportReason
+	Details string
+}
+
+// ReportFilters selects reports from the queue.
+type ReportFilters struct {
+	Page     int
+	PageSize int
+	ReviewID *uuid.UUID
+	Status   models.ReportStatus
+	Reason   models.ReportReason
+}
+
+// ReportListResult wraps reports with pagination info.
+type ReportListResult struct {
+	Data       []models.ReviewReport `json:"data"`
+	Pagination Pagination            `json:"pagination"`
+}
+
+// Report files a complaint about a published review. Each reader may report
+// a review once, and authors cannot report their own reviews. Once the open
+// reports reach the threshold the review is sent back to moderation.
+func (s *ReportService) Report(review *models.Review, reporterID uuid.UUID, input ReportInput) (*models.ReviewReport, error) {
+	reason := models.ReportReason(strings.ToLower(strings.TrimSpace(string(input.Reason))))
+	if !reason.Valid() {
+		return nil, errors.New("reason must be one of spam, offensive, inaccurate, irrelevant, privacy, other")
+	}
+	details := strings.TrimSpace(input.Details)
+	if reason == models.ReportReasonOther && details == "" {
+		return nil, errors.New("details are required when the reason is other")
+	}
+	if utf8.RuneCountInString(details) > maxReportDetails {
+		return nil, fmt.Errorf("details must be at most %d characters", maxReportDetails)
+	}
+
+	if review.Status != models.ReviewStatusApproved {
+		return nil, common.ErrReviewNotReportable
+	}
+	if review.AuthorID == reporterID {
+		return nil, common.ErrOwnReviewReport
+	}
+	reported, err := s.reports.Exists(review.ID, reporterID)
+	if err != nil {
+		return nil, err
+	}
+	if reported {
+		return nil, common.ErrAlreadyReported
+	}
+
+	report := &models.ReviewReport{
+		ID:         uuid.New(),
+		ReviewID:   review.ID,
+		ReporterID: reporterID,
+		Reason:     reason,
+		Details:    details,
+		Status:     models.ReportStatusOpen,
+	}
+	if err := s.reports.Create(report); err != nil {
+		return nil, err
+	}
+
+	s.requeueIfReported(review)
+	return report, nil
+}
+
+// requeueIfReported sends the review back to moderation once its open
+// reports reach the threshold. Failures are logged; the report stands.
+func (s *ReportService) requeueIfReported(review *models.Review) {
+	if s.threshold <= 0 {
+		return
+	}
+	count, err := s.reports.CountOpen(review.ID)
+	if err != nil {
+		log.Printf("count reports on review %s: %v", review.ID, err)
+		return
+	}
+	if count < int64(s.threshold) {
+		return
+	}
+	if err := s.reviews.Requeue(review, fmt.Sprintf("reported by %d readers", count)); err != nil {
+		log.Printf("requeue reported review %s: %v", review.ID, err)
+	}
+}
+
+// List returns a page of reports, oldest first.
+func (s *ReportService) List(filters ReportFilters) (ReportListResult, error) {
+	limit, page, offset := normalizePage(filters.Page, filters.PageSize)
+	result, err := s.reports.List(repository.ReviewReportListOptions{
+		ReviewID: filters.ReviewID,
+		Status:   filters.Status,
+		Reason:   filters.Reason,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		return ReportListResult{}, err
+	}
+
+	return ReportListResult{
+		Data:       result.Reports,
+		Pagination: newPagination(page, limit, result.Total),
+	}, nil
+}
+
+// Get returns a report by ID.
+func (s *ReportService) Get(id uuid.UUID) (*models.ReviewReport, error) {
+	return s.reports.FindByID(id)
+}
+
+// Resolve upholds an open report. Acting on the review itself is left to the
+// review moderation endpoints.
+func (s *ReportService) Resolve(report *models.ReviewReport, adminID uuid.UUID, note string) error {
+	return s.close(report, models.ReportStatusResolved, adminID, note)
+}
+
+// Dismiss closes an open report as unwarranted.
+func (s *ReportService) Dismiss(report *models.ReviewReport, adminID uuid.UUID, note string) error {
+	return s.close(report, models.ReportStatusDismissed, adminID, note)
+}
+
+func (s *ReportService) close(report *models.ReviewReport, status models.ReportStatus, adminID uuid.UUID, note string) error {
+	if report.Status != models.ReportStatusOpen {
+		return common.ErrReportClosed
+	}
+	now := time.Now()
+	report.Status = status
+	report.ResolverID = &adminID
+	report.Note = strings.TrimSpace(note)
+	report.ResolvedAt = &now
+	if err := s.reports.Save(report); err != nil {
+		return err
+	}
+	s.notifications.ReportClosed(report)
+	return nil
+}
+
+// OnReviewEvent closes the open reports on a review once it has been decided
+// on: an administrator approving it dismisses them, while rejecting or
+// deleting it upholds them. It is meant to be subscribed to the review
+// workflow machine.
+func (s *ReportService) OnReviewEvent(event workflow.Event) {
+	var status models.ReportStatus
+	var note string
+	switch event.Action {
+	case workflow.ActionApprove:
+		// Only an administrator may clear readers' reports.
+		if event.Actor != workflow.ActorAdmin {
+			return
+		}
+		status, note = models.ReportStatusDismissed, "review approved"
+	case workflow.ActionReject:
+		status, note = models.ReportStatusResolved, "review rejected"
+	case workflow.ActionDelete:
+		status, note = models.ReportStatusResolved, "review deleted"
+	default:
+		return
+	}
+
+	reports, err := s.reports.CloseOpen(event.ReviewID, status, adminActor(event), note, event.At)
+	if err != nil {
+		log.Printf("close reports on review %s: %v", event.ReviewID, err)
+		return
+	}
+	for i := range reports {
+		s.notifications.ReportClosed(&reports[i])
+	}
+}
@@ -4,26 +4,61 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/hdu-dp/backend/internal/common"
 	"github.com/hdu-dp/backend/internal/models"
+	"github.com/hdu-dp/backend/internal/moderation"
 	"github.com/hdu-dp/backend/internal/repository"
+	"github.com/hdu-dp/backend/internal/similarity"
 	"github.com/hdu-dp/backend/internal/storage"
+	"github.com/hdu-dp/backend/internal/textfilter"
+	"github.com/hdu-dp/backend/internal/workflow"
+	"gorm.io/gorm"
 )
 
 // ReviewService contains business logic around review workflows.
 type ReviewService struct {
 	reviews *repository.ReviewRepository
+	places  *repository.PlaceRepository
 	storage storage.FileStorage
+	prior   models.BayesianPrior
+	machine *workflow.Machine
+	actions *repository.ModerationActionRepository
+	reasons *repository.RejectionReasonRepository
+	reports *repository.ReviewReportRepository
+	filter  *textfilter.Filter
+	// pipeline pre-moderates content entering moderation; nil disables it.
+	pipeline   *moderation.Pipeline
+	duplicates DuplicatePolicy
+	// claimTTL is how long a moderation claim lasts.
+	claimTTL time.Duration
 }
 
-// NewReviewService constructs a review service instance.
-func NewReviewService(reviews *repository.ReviewRepository, fileStorage storage.FileStorage) *ReviewService {
-	return &ReviewService{reviews: reviews, storage: fileStorage}
+// NewReviewService constructs a review service instance. Status changes are
+// validated by machine, which also publishes the resulting events; decisions
+// by administrators and the system are logged to actions. Rejections may
+// reference templates from reasons. Content entering moderation is screened
+// by filter, matched against earlier reviews according to duplicates and
+// then assessed by pipeline, which may be nil; reviews with open reports are
+// never approved automatically.
+func NewReviewService(reviews *repository.ReviewRepository, places *repository.PlaceRepository, fileStorage storage.FileStorage, prior models.BayesianPrior, machine *workflow.Machine, actions *repository.ModerationActionRepository, reasons *repository.RejectionReasonRepository, reports *repository.ReviewReportRepository, filter *textfilter.Filter, pipeline *moderation.Pipeline, duplicates DuplicatePolicy, claimTTL time.Duration) *ReviewService {
+	return &ReviewService{reviews: reviews, places: places, storage: fileStorage, prior: prior, machine: machine, actions: actions, reasons: reasons, reports: reports, filter: filter, pipeline: pipeline, duplicates: duplicates, claimTTL: claimTTL}
+}
+
+// DuplicatePolicy configures how content entering moderation is matched
+// against earlier reviews.
+type DuplicatePolicy struct {
+	// MaxDistance is the largest fingerprint distance, in bits, counted as a
+	// near duplicate. Exact copies always match.
+	MaxDistance int
+	// BlockExact refuses content identical to another of the author's reviews.
+	BlockExact bool
 }
 
 // CreateReviewInput bundles parameters for a new review.
@@ -31,7 +66,42 @@ type CreateReviewInput struct {
 	Title       string
 	Address     string
 	Description string
-	Rating      float32
+	// Rating is the overall score; when nil it is derived from Ratings.
+	Rating  *float32
+	Ratings models.DimensionRatings
+	// PlaceID links the review to an existing place. When nil the address is
+	// matched against known places, creating a pending place if needed.
+	PlaceID   *uuid.UUID
+	PlaceName string
+	// Draft keeps the review private and out of moderation. Drafts may omit
+	// the address and rating until they are submitted.
+	Draft bool
+}
+
+// UpdateReviewInput carries the fields an author may change; nil fields keep
+// their current value. When Ratings is given without Rating the overall rating
+// is derived again from the dimensions.
+type UpdateReviewInput struct {
+	Title       *string
+	Address     *string
+	Description *string
+	Rating      *float32
+	Ratings     *models.DimensionRatings
+}
+
+// AppealInput carries an appeal message and optional changes applied to the
+// review before it is reconsidered.
+type AppealInput struct {
+	Message string
+	Edit    *UpdateReviewInput
+}
+
+// RejectInput carries the grounds for a rejection: a template Code, a
+// free-form Reason, or both. With a code the reason is an optional note and
+// defaults to the template's message.
+type RejectInput struct {
+	Code   string
+	Reason string
 }
 
 // ListFilters describes filters sortable/paginatable lists.
@@ -39,8 +109,19 @@ type ListFilters struct {
 	Page     int
 	PageSize int
 	Query    string
-	SortBy   string
-	SortDir  string
+	PlaceID  *uuid.UUID
+	// HideClaimedFor hides reviews claimed by administrators other than this one.
+	HideClaimedFor *uuid.UUID
+	// FlaggedOnly keeps reviews whose content awaiting moderation was flagged
+	// by the sensitive-word filter.
+	FlaggedOnly bool
+	// DuplicatesOnly keeps reviews whose content awaiting moderation
+	// resembles other reviews.
+	DuplicatesOnly bool
+	// MinRatings keeps reviews scoring at least the given value per dimension.
+	MinRatings map[models.RatingDimension]float32
+	SortBy     string
+	SortDir    string
 }
 
 // Pagination metadata for list responses.
@@ -57,17 +138,18 @@ type ReviewListResult struct {
 	Pagination Pagination      `json:"pagination"`
 }
 
-// Submit creates a new review in pending state.
+// Submit creates a new review in pending state, or a draft when requested.
 func (s *ReviewService) Submit(authorID uuid.UUID, input CreateReviewInput) (*models.Review, error) {
 	title := strings.TrimSpace(input.Title)
 	address := strings.TrimSpace(input.Address)
 	description := strings.TrimSpace(input.Description)
 
-	if title == "" || address == "" {
-		return nil, errors.New("title and address are required")
+	if !input.Draft && (title == "" || (address == "" && input.PlaceID == nil)) {
+		return nil, invalidReview("title and address are required")
 	}
-	if input.Rating < 0 || input.Rating > 5 {
-		return nil, errors.New("rating must be between 0 and 5")
+	rating, err := resolveRatingFor(input.Rating, input.Ratings, input.Draft)
+	if err != nil {
+		return nil, err
 	}
 
 	review := &models.Review{
@@ -75,17 +157,183 @@ func (s *ReviewService) Submit(authorID uuid.UUID, input CreateReviewInput) (*mo
 		Title:       title,
 		Address:     address,
 		Description: description,
-		Rating:      input.Rating,
-		Status:      models.ReviewStatusPending,
+		Rating:      rating,
+		Ratings:     input.Ratings,
+		Status:      models.ReviewStatusDraft,
 		AuthorID:    authorID,
 	}
+	var fingerprint *models.ReviewFingerprint
+	if !input.Draft {
+		content, flags, err := s.screen(contentOf(review))
+		if err != nil {
+			return nil, err
+		}
+		content.applyToReview(review)
+		review.ModerationFlags = flags
+		fingerprint = fingerprintOf(review.ID, authorID, content)
+		if review.Duplicates, err = s.findDuplicates(fingerprint); err != nil {
+			return nil, err
+		}
+	}
 
-	if err := s.reviews.Create(review); err != nil {
+	// A direct submission is a draft submitted right away.
+	var events []workflow.Event
+	if !input.Draft {
+		event, err := s.machine.Apply(review, workflow.ActionSubmit, workflow.ActorAuthor, authorID, "")
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	var place *models.Place
+	err = s.reviews.Transaction(func(tx *gorm.DB) error {
+		// A draft without any location is linked once it is submitted.
+		if input.PlaceID != nil || address != "" {
+			var err error
+			place, err = resolvePlace(s.places.WithTx(tx), authorID, input.PlaceID, strings.TrimSpace(input.PlaceName), address)
+			if err != nil {
+				return err
+			}
+			if review.Address == "" {
+				review.Address = place.Address
+			}
+			review.PlaceID = &place.ID
+		}
+		if err := s.reviews.WithTx(tx).Create(review); err != nil {
+			return err
+		}
+		if fingerprint == nil {
+			return nil
+		}
+		return s.reviews.WithTx(tx).SaveFingerprint(fingerprint)
+	})
+	if err != nil {
 		return nil, err
 	}
+
+	review.Place = place
+	s.machine.Publish(events...)
+	if !input.Draft {
+		s.premoderate(review)
+	}
 	return review, nil
 }
 
+// SubmitForModeration sends a draft or withdrawn review to the moderation
+// queue after checking it is complete, linking it to a place if it has none.
+func (s *ReviewService) SubmitForModeration(review *models.Review) error {
+	if err := s.machine.Check(workflow.ActionSubmit, review.Status, workflow.ActorAuthor); err != nil {
+		return err
+	}
+	if err := contentOf(review).validateComplete(); err != nil {
+		return err
+	}
+	content, flags, err := s.screen(contentOf(review))
+	if err != nil {
+		return err
+	}
+	fingerprint := fingerprintOf(review.ID, review.AuthorID, content)
+	duplicates, err := s.findDuplicates(fingerprint)
+	if err != nil {
+		return err
+	}
+
+	event, err := s.machine.Apply(review, workflow.ActionSubmit, workflow.ActorAuthor, review.AuthorID, "")
+	if err != nil {
+		return err
+	}
+	content.applyToReview(review)
+	review.ModerationFlags = flags
+	review.Duplicates = duplicates
+	err = s.reviews.Transaction(func(tx *gorm.DB) error {
+		if err := s.linkPlace(tx, review); err != nil {
+			return err
+		}
+		if err := s.reviews.WithTx(tx).Update(review); err != nil {
+			return err
+		}
+		return s.reviews.WithTx(tx).SaveFingerprint(fingerprint)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.machine.Publish(event)
+	s.premoderate(review)
+	return nil
+}
+
+// linkPlace points a review without a place at the one matching its address,
+// creating a pending place if none does.
+func (s *ReviewService) linkPlace(tx *gorm.DB, review *models.Review) error {
+	if review.PlaceID != nil || review.Address == "" {
+		return nil
+	}
+	place, err := resolvePlace(s.places.WithTx(tx), review.AuthorID, nil, "", review.Address)
+	if err != nil {
+		return err
+	}
+	review.PlaceID = &place.ID
+	review.Place = place
+	return nil
+}
+
+// unlinkPlace detaches a review from its place after its address changed.
+func unlinkPlace(review *models.Review) {
+	review.PlaceID = nil
+	review.Place = nil
+}
+
+// resolveRating validates the supplied scores and returns the overall rating,
+// deriving it from the dimension scores when not given explicitly.
+func resolveRating(overall *float32, dims models.DimensionRatings) (float32, error) {
+	for dim, v := range dims.Values() {
+		if v < 0 || v > 5 {
+			return 0, invalidReview(fmt.Sprintf("%s rating must be between 0 and 5", dim))
+		}
+	}
+	if overall == nil {
+		avg, ok := dims.Average()
+		if !ok {
+			return 0, invalidReview("rating is required")
+		}
+		return avg, nil
+	}
+	if *overall < 0 || *overall > 5 {
+		return 0, invalidReview("rating must be between 0 and 5")
+	}
+	return *overall, nil
+}
+
+// validationError is an input validation failure. It matches kind while
+// keeping its own message.
+type validationError struct {
+	kind error
+	msg  string
+}
+
+func invalidReview(msg string) error {
+	return validationError{kind: common.ErrInvalidReview, msg: msg}
+}
+
+func (e validationError) Error() string {
+	return e.msg
+}
+
+func (e validationError) Is(target error) bool {
+	return target == e.kind
+}
+
+// resolveRatingFor is resolveRating for submissions that may be drafts: a
+// draft without any score gets a zero rating to be filled in later.
+func resolveRatingFor(overall *float32, dims models.DimensionRatings, draft bool) (float32, error) {
+	if draft && overall == nil && len(dims.Values()) == 0 {
+		return 0, nil
+	}
+	return resolveRating(overall, dims)
+}
+
 // ListPublic returns approved reviews.
 func (s *ReviewService) ListPublic(filters ListFilters) (ReviewListResult, error) {
 	opts := buildListOptions(filters)
@@ -97,33 +345,44 @@ func (s *ReviewService) ListPublic(filters ListFilters) (ReviewListResult, error
 func (s *ReviewService) ListByAuthor(authorID uuid.UUID, filters ListFilters) (ReviewListResult, error) {
 	opts := buildListOptions(filters)
 	opts.AuthorID = &authorID
+	opts.PreloadEdits = true
+	opts.PreloadAppeals = true
 	return s.listWithPagination(opts, filters)
 }
 
-// ListPending returns pending reviews for admin review.
+// ListPending returns pending reviews and approved reviews with pending edits
+// for admin review.
 func (s *ReviewService) ListPending(filters ListFilters) (ReviewListResult, error) {
 	opts := buildListOptions(filters)
 	opts.Statuses = []models.ReviewStatus{models.ReviewStatusPending}
+	opts.WithPendingEdits = true
+	opts.PreloadEdits = true
+	opts.PreloadAssessments = true
+	return s.listWithPagination(opts, filters)
+}
+
+// ListAppealed returns reviews waiting for an appeal decision.
+func (s *ReviewService) ListAppealed(filters ListFilters) (ReviewListResult, error) {
+	opts := buildListOptions(filters)
+	opts.Statuses = []models.ReviewStatus{models.ReviewStatusAppealed}
+	opts.PreloadAppeals = true
 	return s.listWithPagination(opts, filters)
 }
 
 func buildListOptions(filters ListFilters) repository.ListOptions {
-	limit := filters.PageSize
-	if limit <= 0 {
-		limit = 10
-	}
-	page := filters.Page
-	if page <= 0 {
-		page = 1
-	}
-	offset := (page - 1) * limit
+	limit, _, offset := normalizePage(filters.Page, filters.PageSize)
 
 	return repository.ListOptions{
-		Query:   filters.Query,
-		SortBy:  filters.SortBy,
-		SortDir: filters.SortDir,
-		Limit:   limit,
-		Offset:  offset,
+		PlaceID:        filters.PlaceID,
+		HideClaimedFor: filters.HideClaimedFor,
+		FlaggedOnly:    filters.FlaggedOnly,
+		DuplicatesOnly: filters.DuplicatesOnly,
+		MinRatings:     filters.MinRatings,
+		Query:          filters.Query,
+		SortBy:         filters.SortBy,
+		SortDir:        filters.SortDir,
+		Limit:          limit,
+		Offset:         offset,
 	}
 }
 
@@ -133,26 +392,33 @@ func (s *ReviewService) listWithPagination(opts repository.ListOptions, filters
 		return ReviewListResult{}, err
 	}
 
-	limit := opts.Limit
+	limit, page, _ := normalizePage(filters.Page, opts.Limit)
+
+	return ReviewListResult{
+		Data:       result.Reviews,
+		Pagination: newPagination(page, limit, result.Total),
+	}, nil
+}
+
+// normalizePage applies paging defaults and returns limit, page and offset.
+func normalizePage(page, pageSize int) (int, int, int) {
+	limit := pageSize
 	if limit <= 0 {
 		limit = 10
 	}
-	page := filters.Page
 	if page <= 0 {
 		page = 1
 	}
+	return limit, page, (page - 1) * limit
+}
 
-	totalPages := int((result.Total + int64(limit) - 1) / int64(limit))
-
-	return ReviewListResult{
-		Data: result.Reviews,
-		Pagination: Pagination{
-			Page:       page,
-			PageSize:   limit,
-			Total:      result.Total,
-			TotalPages: totalPages,
-		},
-	}, nil
+func newPagination(page, limit int, total int64) Pagination {
+	return Pagination{
+		Page:       page,
+		PageSize:   limit,
+		Total:      total,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	}
 }
 
 // Get returns a review by ID.
@@ -160,24 +426,715 @@ func (s *ReviewService) Get(id uuid.UUID) (*models.Review, error) {
 	return s.reviews.FindByID(id)
 }
 
-// Approve marks a review as approved.
-func (s *ReviewService) Approve(review *models.Review) error {
-	if review.Status != models.ReviewStatusPending {
-		return common.ErrReviewAlreadyProcessed
+// Edit applies author changes to a review. Drafts are updated in place
+// without leaving draft state. Pending, rejected and withdrawn reviews are
+// updated in place and (re-)enter moderation; changes to an approved review
+// are stored as a pending edit so the approved version stays public until
+// the edit is approved. Every effective change outside drafts is recorded as
+// a revision.
+func (s *ReviewService) Edit(review *models.Review, editorID uuid.UUID, input UpdateReviewInput) error {
+	current := contentOf(review)
+	if review.PendingEdit != nil {
+		current = editContent(review.PendingEdit)
+	}
+
+	draft := review.Status == models.ReviewStatusDraft
+	next, err := current.apply(input, draft)
+	if err != nil {
+		return err
+	}
+	var flags []string
+	var fingerprint *models.ReviewFingerprint
+	var duplicates []models.DuplicateMatch
+	if !draft {
+		if next, flags, err = s.screen(next); err != nil {
+			return err
+		}
+		fingerprint = fingerprintOf(review.ID, review.AuthorID, next)
+		if duplicates, err = s.findDuplicates(fingerprint); err != nil {
+			return err
+		}
+	}
+
+	changes := diffContent(current, next)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	// Drafts are private scratch space; their history starts on submission.
+	if draft {
+		next.applyToReview(review)
+		if next.Address != current.Address {
+			// Linked again to a place once submitted.
+			unlinkPlace(review)
+		}
+		return s.reviews.Update(review)
+	}
+
+	action := workflow.ActionEdit
+	if review.Status == models.ReviewStatusApproved {
+		action = workflow.ActionProposeEdit
+	}
+	event, err := s.machine.Apply(review, action, workflow.ActorAuthor, editorID, "")
+	if err != nil {
+		return err
+	}
+
+	revision := &models.ReviewRevision{
+		ReviewID: review.ID,
+		EditorID: editorID,
+		Changes:  changes,
+	}
+
+	err = s.reviews.Transaction(func(tx *gorm.DB) error {
+		reviews := s.reviews.WithTx(tx)
+
+		if action == workflow.ActionProposeEdit {
+			// The pending edit is part of the versioned review, so proposing
+			// one checks and bumps the version like any other change.
+			if err := reviews.Update(review); err != nil {
+				return err
+			}
+			edit := review.PendingEdit
+			if edit == nil {
+				edit = &models.ReviewEdit{ID: uuid.New(), ReviewID: review.ID}
+			}
+			edit.EditorID = editorID
+			edit.ModerationFlags = flags
+			edit.Duplicates = duplicates
+			next.applyToEdit(edit)
+			if err := reviews.SaveEdit(edit); err != nil {
+				return err
+			}
+			review.PendingEdit = edit
+			revision.Kind = models.RevisionKindProposed
+		} else {
+			next.applyToReview(review)
+			clearRejection(review)
+			review.ModerationFlags = flags
+			review.Duplicates = duplicates
+			if next.Address != current.Address {
+				unlinkPlace(review)
+				if err := s.linkPlace(tx, review); err != nil {
+					return err
+				}
+			}
+			if err := reviews.Update(review); err != nil {
+				return err
+			}
+			if err := reviews.SaveFingerprint(fingerprint); err != nil {
+				return err
+			}
+			revision.Kind = models.RevisionKindApplied
+		}
+
+		return reviews.CreateRevision(revision)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.machine.Publish(event)
+	s.premoderate(review)
+	return nil
+}
+
+// ListRevisions returns the change history of a review, oldest first.
+func (s *ReviewService) ListRevisions(reviewID uuid.UUID) ([]models.ReviewRevision, error) {
+	return s.reviews.ListRevisions(reviewID)
+}
+
+// ReviewDiff shows moderators what changed in a review awaiting moderation.
+type ReviewDiff struct {
+	ReviewID uuid.UUID `json:"review_id"`
+	// Source is "pending_edit" when comparing the public content with a
+	// pending edit, "latest_revision" when showing the most recent in-place
+	// change, or "none".
+	Source  string               `json:"source"`
+	Changes []models.FieldChange `json:"changes"`
+}
+
+// Diff returns the per-field changes a moderator is asked to approve.
+func (s *ReviewService) Diff(review *models.Review) (ReviewDiff, error) {
+	diff := ReviewDiff{ReviewID: review.ID, Source: "none", Changes: []models.FieldChange{}}
+
+	if review.PendingEdit != nil {
+		diff.Source = "pending_edit"
+		diff.Changes = diffContent(contentOf(review), editContent(review.PendingEdit))
+		return diff, nil
+	}
+
+	latest, err := s.reviews.LatestRevision(review.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return diff, nil
+		}
+		return ReviewDiff{}, err
+	}
+	diff.Source = "latest_revision"
+	diff.Changes = latest.Changes
+	return diff, nil
+}
+
+// Approve marks a review as approved. For an approved review with a pending
+// edit, the edit replaces the public content.
+func (s *ReviewService) Approve(review *models.Review, actor workflow.Actor, actorID uuid.UUID) error {
+	if err := checkClaim(review, actor, actorID); err != nil {
+		return err
+	}
+	before := contributionOf(review)
+	action := workflow.ActionApprove
+	if review.Status == models.ReviewStatusApproved && review.PendingEdit != nil {
+		action = workflow.ActionApproveEdit
+	}
+
+	event, err := s.machine.Apply(review, action, actor, actorID, "")
+	if err != nil {
+		return err
+	}
+	if action == workflow.ActionApproveEdit {
+		if review.PendingEdit.Address != review.Address {
+			// Linked to the place matching the new address on commit.
+			unlinkPlace(review)
+		}
+		editContent(review.PendingEdit).applyToReview(review)
+		review.PendingEdit = nil
+	}
+	clearRejection(review)
+	review.ModerationFlags = nil
+	review.Duplicates = nil
+	return s.commit(review, before, event)
+}
+
+// Reject marks a review as rejected on the given grounds. For an approved
+// review with a pending edit, only the edit is discarded and the approved
+// content stays; the reason is kept for the author.
+func (s *ReviewService) Reject(review *models.Review, actor workflow.Actor, actorID uuid.UUID, input RejectInput) error {
+	if err := checkClaim(review, actor, actorID); err != nil {
+		return err
+	}
+	template, err := s.rejectionTemplate(input.Code)
+	if err != nil {
+		return err
+	}
+	before := contributionOf(review)
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" && template != nil {
+		reason = template.Message
+	}
+	action := workflow.ActionReject
+	if review.Status == models.ReviewStatusApproved && review.PendingEdit != nil {
+		action = workflow.ActionRejectEdit
+	}
+
+	event, err := s.machine.Apply(review, action, actor, actorID, reason)
+	if err != nil {
+		return err
+	}
+	if action == workflow.ActionRejectEdit {
+		review.PendingEdit = nil
+	}
+	review.RejectionReason = reason
+	review.ModerationFlags = nil
+	review.Duplicates = nil
+	review.RejectionCode = ""
+	review.RejectionMessage = ""
+	if template != nil {
+		review.RejectionCode = template.Code
+		review.RejectionMessage = template.Message
+	}
+	return s.commit(review, before, event)
+}
+
+// Requeue sends a published review back to moderation as the system, for
+// instance after readers reported it. It leaves public listings and its
+// place's rating until an administrator approves it again.
+func (s *ReviewService) Requeue(review *models.Review, reason string) error {
+	before := contributionOf(review)
+	event, err := s.machine.Apply(review, workflow.ActionRequeue, workflow.ActorSystem, uuid.Nil, reason)
+	if err != nil {
+		return err
+	}
+	return s.commit(review, before, event)
+}
+
+// rejectionTemplate returns the active template for code, or nil when no code
+// is given.
+func (s *ReviewService) rejectionTemplate(code string) (*models.RejectionReason, error) {
+	code = normalizeRejectionCode(code)
+	if code == "" {
+		return nil, nil
+	}
+	template, err := s.reasons.FindByCode(code)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && !template.Active) {
+		return nil, common.ErrUnknownRejectionReason
+	}
+	if err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// LocalizeRejections fills RejectionMessage of reviews rejected with a
+// template, using the first of langs the template is translated to. Reviews
+// whose template has been deleted keep only their rejection reason.
+func (s *ReviewService) LocalizeRejections(reviews []models.Review, langs []string) {
+	var codes []string
+	for i := range reviews {
+		if reviews[i].RejectionCode != "" {
+			codes = append(codes, reviews[i].RejectionCode)
+		}
+	}
+	if len(codes) == 0 {
+		return
+	}
+
+	templates, err := s.reasons.FindByCodes(codes)
+	if err != nil {
+		log.Printf("localize rejections: %v", err)
+		return
+	}
+	byCode := make(map[string]*models.RejectionReason, len(templates))
+	for i := range templates {
+		byCode[templates[i].Code] = &templates[i]
 	}
-	review.Status = models.ReviewStatusApproved
+	for i := range reviews {
+		if template, ok := byCode[reviews[i].RejectionCode]; ok {
+			reviews[i].RejectionMessage = template.Localize(langs)
+		}
+	}
+}
+
+// LocalizeRejection fills RejectionMessage of a single review as
+// LocalizeRejections does.
+func (s *ReviewService) LocalizeRejection(review *models.Review, langs []string) {
+	if review.RejectionCode == "" {
+		return
+	}
+	reviews := []models.Review{*review}
+	s.LocalizeRejections(reviews, langs)
+	review.RejectionMessage = reviews[0].RejectionMessage
+}
+
+// clearRejection drops the rejection grounds once a review is no longer
+// rejected.
+func clearRejection(review *models.Review) {
 	review.RejectionReason = ""
-	return s.reviews.Update(review)
+	review.RejectionCode = ""
+	review.RejectionMessage = ""
+}
+
+// Appeal files the author's appeal against a rejection, applying any edits
+// in the same step. A review can only be appealed once.
+func (s *ReviewService) Appeal(review *models.Review, input AppealInput) error {
+	if err := s.machine.Check(workflow.ActionAppeal, review.Status, workflow.ActorAuthor); err != nil {
+		return err
+	}
+	message := strings.TrimSpace(input.Message)
+	if message == "" {
+		return errors.New("appeal message is required")
+	}
+	appealed, err := s.reviews.HasAppeal(review.ID)
+	if err != nil {
+		return err
+	}
+	if appealed {
+		return common.ErrAppealExists
+	}
+
+	var changes []models.FieldChange
+	var fingerprint *models.ReviewFingerprint
+	next := contentOf(review)
+	if input.Edit != nil {
+		current := next
+		if next, err = current.apply(*input.Edit, false); err != nil {
+			return err
+		}
+		if next, review.ModerationFlags, err = s.screen(next); err != nil {
+			return err
+		}
+		fingerprint = fingerprintOf(review.ID, review.AuthorID, next)
+		if review.Duplicates, err = s.findDuplicates(fingerprint); err != nil {
+			return err
+		}
+		changes = diffContent(current, next)
+	}
+
+	appeal := &models.ReviewAppeal{
+		ReviewID:        review.ID,
+		AuthorID:        review.AuthorID,
+		Message:         message,
+		RejectionReason: review.RejectionReason,
+		Status:          models.AppealStatusOpen,
+	}
+	event, err := s.machine.Apply(review, workflow.ActionAppeal, workflow.ActorAuthor, review.AuthorID, message)
+	if err != nil {
+		return err
+	}
+
+	err = s.reviews.Transaction(func(tx *gorm.DB) error {
+		reviews := s.reviews.WithTx(tx)
+		next.applyToReview(review)
+		if err := reviews.Update(review); err != nil {
+			return err
+		}
+		if fingerprint != nil {
+			if err := reviews.SaveFingerprint(fingerprint); err != nil {
+				return err
+			}
+		}
+		if len(changes) > 0 {
+			if err := reviews.CreateRevision(&models.ReviewRevision{
+				ReviewID: review.ID,
+				EditorID: review.AuthorID,
+				Kind:     models.RevisionKindApplied,
+				Changes:  changes,
+			}); err != nil {
+				return err
+			}
+		}
+		return reviews.CreateAppeal(appeal)
+	})
+	if err != nil {
+		return err
+	}
+
+	review.Appeal = appeal
+	s.machine.Publish(event)
+	return nil
 }
 
-// Reject marks a review as rejected with reason.
-func (s *ReviewService) Reject(review *models.Review, reason string) error {
-	if review.Status != models.ReviewStatusPending {
+// ResolveAppeal records an administrator's decision on an open appeal.
+// Accepting publishes the review; denying returns it to rejected.
+func (s *ReviewService) ResolveAppeal(review *models.Review, adminID uuid.UUID, accept bool, response string) error {
+	action, outcome := workflow.ActionDenyAppeal, models.AppealStatusDenied
+	if accept {
+		action, outcome = workflow.ActionAcceptAppeal, models.AppealStatusAccepted
+	}
+	if err := s.machine.Check(action, review.Status, workflow.ActorAdmin); err != nil {
+		return err
+	}
+	if err := checkClaim(review, workflow.ActorAdmin, adminID); err != nil {
+		return err
+	}
+	appeal := review.Appeal
+	if appeal == nil || appeal.Status != models.AppealStatusOpen {
 		return common.ErrReviewAlreadyProcessed
 	}
-	review.Status = models.ReviewStatusRejected
-	review.RejectionReason = strings.TrimSpace(reason)
-	return s.reviews.Update(review)
+
+	before := contributionOf(review)
+	response = strings.TrimSpace(response)
+	event, err := s.machine.Apply(review, action, workflow.ActorAdmin, adminID, response)
+	if err != nil {
+		return err
+	}
+	if accept {
+		clearRejection(review)
+	}
+	review.ModerationFlags = nil
+	review.Duplicates = nil
+
+	now := time.Now()
+	appeal.Status = outcome
+	appeal.ResolverID = &adminID
+	appeal.Response = response
+	appeal.ResolvedAt = &now
+
+	err = s.reviews.Transaction(func(tx *gorm.DB) error {
+		if err := s.reviews.WithTx(tx).SaveAppeal(appeal); err != nil {
+			return err
+		}
+		if err := s.reviews.WithTx(tx).Update(review); err != nil {
+			return err
+		}
+		if err := s.reviews.WithTx(tx).ReleaseClaim(review.ID); err != nil {
+			return err
+		}
+		if err := s.recordAction(tx, event); err != nil {
+			return err
+		}
+		return applyContribution(s.places.WithTx(tx), s.prior, before, contributionOf(review))
+	})
+	if err != nil {
+		return err
+	}
+
+	review.ClaimedByID = nil
+	review.ClaimExpiresAt = nil
+	s.machine.Publish(event)
+	return nil
+}
+
+// MaxBulkItems caps the number of reviews handled by one bulk request.
+const MaxBulkItems = 100
+
+// BulkItemResult reports the outcome of a bulk operation for one review.
+type BulkItemResult struct {
+	ID     uuid.UUID           `json:"id"`
+	OK     bool                `json:"ok"`
+	Status models.ReviewStatus `json:"status,omitempty"`
+	Error  string              `json:"error,omitempty"`
+}
+
+// BulkResult lists per-review outcomes of a bulk operation in request order.
+type BulkResult struct {
+	Results   []BulkItemResult `json:"results"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+}
+
+// BulkApprove approves each review as Approve would.
+func (s *ReviewService) BulkApprove(ids []uuid.UUID, actor workflow.Actor, actorID uuid.UUID) (BulkResult, error) {
+	return s.bulk(ids, func(review *models.Review) error {
+		return s.Approve(review, actor, actorID)
+	})
+}
+
+// BulkReject rejects each review on the same grounds as Reject would.
+func (s *ReviewService) BulkReject(ids []uuid.UUID, actor workflow.Actor, actorID uuid.UUID, input RejectInput) (BulkResult, error) {
+	if _, err := s.rejectionTemplate(input.Code); err != nil {
+		return BulkResult{}, err
+	}
+	return s.bulk(ids, func(review *models.Review) error {
+		return s.Reject(review, actor, actorID, input)
+	})
+}
+
+// BulkDelete moves each review to the trash as DeleteReview would.
+func (s *ReviewService) BulkDelete(ids []uuid.UUID, actor workflow.Actor, actorID uuid.UUID, reason string) (BulkResult, error) {
+	return s.bulk(ids, func(review *models.Review) error {
+		return s.DeleteReview(review, actor, actorID, reason)
+	})
+}
+
+// bulk applies op to each review independently: every item is committed in
+// its own transaction, so one failure does not roll back the others.
+func (s *ReviewService) bulk(ids []uuid.UUID, op func(*models.Review) error) (BulkResult, error) {
+	if len(ids) == 0 || len(ids) > MaxBulkItems {
+		return BulkResult{}, fmt.Errorf("between 1 and %d review ids are required", MaxBulkItems)
+	}
+
+	result := BulkResult{Results: make([]BulkItemResult, 0, len(ids))}
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		item := BulkItemResult{ID: id}
+		review, err := s.reviews.FindByID(id)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			item.Error = "review not found"
+		case err != nil:
+			item.Error = err.Error()
+		default:
+			if err := op(review); err != nil {
+				item.Error = err.Error()
+			} else {
+				item.OK = true
+			}
+			item.Status = review.Status
+		}
+
+		if item.OK {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+		result.Results = append(result.Results, item)
+	}
+	return result, nil
+}
+
+// commit persists a transitioned review, logs the decision and moves its
+// rating between place aggregates in one transaction, then publishes the
+// event.
+func (s *ReviewService) commit(review *models.Review, before ratingContribution, event workflow.Event) error {
+	err := s.reviews.Transaction(func(tx *gorm.DB) error {
+		reviews := s.reviews.WithTx(tx)
+		// An approved edit may have moved the review to another address.
+		if event.Action == workflow.ActionApproveEdit {
+			if err := s.linkPlace(tx, review); err != nil {
+				return err
+			}
+		}
+		if err := reviews.Update(review); err != nil {
+			return err
+		}
+		if review.PendingEdit == nil {
+			if err := reviews.DeleteEdit(review.ID); err != nil {
+				return err
+			}
+		}
+		// An approved edit becomes the text later submissions are matched against.
+		if event.Action == workflow.ActionApproveEdit {
+			if err := reviews.SaveFingerprint(fingerprintOf(review.ID, review.AuthorID, contentOf(review))); err != nil {
+				return err
+			}
+		}
+		// The decision ends any moderation claim.
+		if err := reviews.ReleaseClaim(review.ID); err != nil {
+			return err
+		}
+		if err := s.recordAction(tx, event); err != nil {
+			return err
+		}
+		return applyContribution(s.places.WithTx(tx), s.prior, before, contributionOf(review))
+	})
+	if err != nil {
+		return err
+	}
+
+	review.ClaimedByID = nil
+	review.ClaimExpiresAt = nil
+	s.machine.Publish(event)
+	return nil
+}
+
+// awaitingModeration reports whether a review has something for an
+// administrator to decide on.
+func awaitingModeration(review *models.Review) bool {
+	switch review.Status {
+	case models.ReviewStatusPending, models.ReviewStatusAppealed:
+		return true
+	case models.ReviewStatusApproved:
+		return review.PendingEdit != nil
+	default:
+		return false
+	}
+}
+
+// checkClaim stops an administrator from deciding on a review another
+// administrator has claimed. The system is not bound by claims.
+func checkClaim(review *models.Review, actor workflow.Actor, actorID uuid.UUID) error {
+	if actor == workflow.ActorAdmin && review.ClaimedByOther(actorID, time.Now()) {
+		return common.ErrReviewClaimed
+	}
+	return nil
+}
+
+// Claim gives an administrator a lease on a review awaiting moderation so
+// other administrators do not handle it at the same time. Claiming again
+// extends the lease.
+func (s *ReviewService) Claim(review *models.Review, adminID uuid.UUID) error {
+	if !awaitingModeration(review) {
+		return common.ErrReviewNotClaimable
+	}
+
+	now := time.Now()
+	until := now.Add(s.claimTTL)
+	granted, err := s.reviews.Claim(review.ID, adminID, until, now)
+	if err != nil {
+		return err
+	}
+	if !granted {
+		return common.ErrReviewClaimed
+	}
+
+	review.ClaimedByID = &adminID
+	review.ClaimExpiresAt = &until
+	return nil
+}
+
+// ReleaseClaim gives up an administrator's claim on a review. Releasing a
+// review nobody holds is a no-op.
+func (s *ReviewService) ReleaseClaim(review *models.Review, adminID uuid.UUID) error {
+	if review.ClaimedByOther(adminID, time.Now()) {
+		return common.ErrReviewClaimed
+	}
+	if err := s.reviews.ReleaseClaim(review.ID); err != nil {
+		return err
+	}
+
+	review.ClaimedByID = nil
+	review.ClaimExpiresAt = nil
+	return nil
+}
+
+// recordAction adds moderation decisions to the audit log. Authors managing
+// their own reviews are not moderation and are not logged.
+func (s *ReviewService) recordAction(tx *gorm.DB, event workflow.Event) error {
+	if event.Actor == workflow.ActorAuthor {
+		return nil
+	}
+	action := &models.ModerationAction{
+		ActorRole: string(event.Actor),
+		ReviewID:  event.ReviewID,
+		AuthorID:  event.AuthorID,
+		Action:    string(event.Action),
+		Reason:    event.Reason,
+		FromState: event.From,
+		ToState:   event.To,
+		CreatedAt: event.At,
+	}
+	if event.Actor != workflow.ActorSystem {
+		actorID := event.ActorID
+		action.ActorID = &actorID
+	}
+	return s.actions.WithTx(tx).Create(action)
+}
+
+// ModerationActionFilters selects entries of the moderation audit log.
+type ModerationActionFilters struct {
+	Page     int
+	PageSize int
+	ReviewID *uuid.UUID
+	AuthorID *uuid.UUID
+	ActorID  *uuid.UUID
+	Action   string
+	From     time.Time
+	To       time.Time
+}
+
+// ModerationActionListResult wraps audit log entries with pagination info.
+type ModerationActionListResult struct {
+	Data       []models.ModerationAction `json:"data"`
+	Pagination Pagination                `json:"pagination"`
+}
+
+// ListModerationActions queries the moderation audit log, newest first.
+func (s *ReviewService) ListModerationActions(filters ModerationActionFilters) (ModerationActionListResult, error) {
+	limit, page, offset := normalizePage(filters.Page, filters.PageSize)
+	result, err := s.actions.List(repository.ModerationActionListOptions{
+		ReviewID: filters.ReviewID,
+		AuthorID: filters.AuthorID,
+		ActorID:  filters.ActorID,
+		Action:   filters.Action,
+		From:     filters.From,
+		To:       filters.To,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		return ModerationActionListResult{}, err
+	}
+
+	return ModerationActionListResult{
+		Data:       result.Actions,
+		Pagination: newPagination(page, limit, result.Total),
+	}, nil
+}
+
+// Withdraw pulls a review out of the moderation queue without deleting it: a
+// pending review becomes withdrawn, and a pending edit of an approved review
+// is discarded while the approved content stays public.
+func (s *ReviewService) Withdraw(review *models.Review) error {
+	before := contributionOf(review)
+	action := workflow.ActionWithdraw
+	if review.Status == models.ReviewStatusApproved && review.PendingEdit != nil {
+		action = workflow.ActionWithdrawEdit
+	}
+
+	event, err := s.machine.Apply(review, action, workflow.ActorAuthor, review.AuthorID, "")
+	if err != nil {
+		return err
+	}
+	if action == workflow.ActionWithdrawEdit {
+		review.PendingEdit = nil
+	}
+	return s.commit(review, before, event)
 }
 
 // StoreImage saves the uploaded file via storage provider and records metadata.
@@ -208,35 +1165,453 @@ func (s *ReviewService) StoreImage(ctx context.Context, reviewID uuid.UUID, file
 	return image, nil
 }
 
-// DeleteReview removes a review and attempts to clean up related assets.
-func (s *ReviewService) DeleteReview(ctx context.Context, review *models.Review) error {
+// DeleteReview moves a review to the trash. Its rating leaves the place
+// aggregates immediately; images stay in storage until the review is purged.
+func (s *ReviewService) DeleteReview(review *models.Review, actor workflow.Actor, actorID uuid.UUID, reason string) error {
 	if review == nil {
 		return errors.New("review is required")
 	}
+	if err := checkClaim(review, actor, actorID); err != nil {
+		return err
+	}
+
+	before := contributionOf(review)
+	event := workflow.NewEvent(review, workflow.ActionDelete, actor, actorID, strings.TrimSpace(reason))
+	review.DeletedAt = gorm.DeletedAt{Time: event.At, Valid: true}
+	review.DeletedByID = &actorID
+	review.DeletionReason = event.Reason
+
+	err := s.reviews.Transaction(func(tx *gorm.DB) error {
+		if err := s.reviews.WithTx(tx).SoftDelete(review); err != nil {
+			return err
+		}
+		if err := s.recordAction(tx, event); err != nil {
+			return err
+		}
+		return applyContribution(s.places.WithTx(tx), s.prior, before, ratingContribution{})
+	})
+	if err != nil {
+		return err
+	}
+
+	s.machine.Publish(event)
+	return nil
+}
+
+// GetDeleted returns a trashed review by ID.
+func (s *ReviewService) GetDeleted(id uuid.UUID) (*models.Review, error) {
+	return s.reviews.FindDeletedByID(id)
+}
+
+// ListTrash returns soft-deleted reviews for administrators.
+func (s *ReviewService) ListTrash(filters ListFilters) (ReviewListResult, error) {
+	opts := buildListOptions(filters)
+	result, err := s.reviews.ListDeleted(opts)
+	if err != nil {
+		return ReviewListResult{}, err
+	}
+
+	limit, page, _ := normalizePage(filters.Page, opts.Limit)
+	return ReviewListResult{
+		Data:       result.Reviews,
+		Pagination: newPagination(page, limit, result.Total),
+	}, nil
+}
+
+// RestoreReview takes a review out of the trash and counts it towards its
+// place again if it is approved.
+func (s *ReviewService) RestoreReview(review *models.Review, adminID uuid.UUID) error {
+	event := workflow.NewEvent(review, workflow.ActionRestore, workflow.ActorAdmin, adminID, "")
+	err := s.reviews.Transaction(func(tx *gorm.DB) error {
+		if err := s.reviews.WithTx(tx).Restore(review.ID); err != nil {
+			return err
+		}
+		if err := s.recordAction(tx, event); err != nil {
+			return err
+		}
+		return applyContribution(s.places.WithTx(tx), s.prior, ratingContribution{}, contributionOf(review))
+	})
+	if err != nil {
+		return err
+	}
+
+	review.DeletedAt = gorm.DeletedAt{}
+	review.DeletedByID = nil
+	review.DeletionReason = ""
+	review.Version++
+	s.machine.Publish(event)
+	return nil
+}
+
+// PurgeTrash permanently removes reviews that have been in the trash longer
+// than retention, deleting their images from storage. It returns the number
+// of purged reviews; a review whose images cannot be deleted is still purged
+// and the first such error is returned.
+func (s *ReviewService) PurgeTrash(ctx context.Context, retention time.Duration) (int, error) {
+	expired, err := s.reviews.ListDeletedBefore(time.Now().Add(-retention))
+	if err != nil {
+		return 0, err
+	}
+
+	var firstErr error
+	purged := 0
+	for _, review := range expired {
+		if err := s.reviews.Purge(review.ID); err != nil {
+			return purged, err
+		}
+		purged++
 
-	var keys []string
-	if len(review.Images) > 0 {
-		keys = make([]string, 0, len(review.Images))
 		for _, image := range review.Images {
-			if image.StorageKey != "" {
-				keys = append(keys, image.StorageKey)
+			if image.StorageKey == "" {
+				continue
+			}
+			if err := s.storage.Delete(ctx, image.StorageKey); err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("delete image %s: %w", image.StorageKey, err)
 			}
 		}
 	}
 
-	if err := s.reviews.Delete(review.ID); err != nil {
-		return err
+	return purged, firstErr
+}
+
+// RunTrashPurger calls PurgeTrash every interval until ctx is cancelled.
+func (s *ReviewService) RunTrashPurger(ctx context.Context, interval, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := s.PurgeTrash(ctx, retention)
+			if err != nil {
+				log.Printf("purge review trash: %v", err)
+			}
+			if purged > 0 {
+				log.Printf("purged %d reviews from trash", purged)
+			}
+		}
+	}
+}
+
+// reviewContent is the author-editable part of a review or pending edit.
+type reviewContent struct {
+	Title       string
+	Address     string
+	Description string
+	Rating      float32
+	Ratings     models.DimensionRatings
+}
+
+// premoderate assesses content that has just entered moderation and, when
+// the policy allows, approves or rejects it as the system. The assessment is
+// kept for administrators; if anything fails the review simply stays queued.
+func (s *ReviewService) premoderate(review *models.Review) {
+	if s.pipeline == nil {
+		return
 	}
 
-	for _, key := range keys {
-		if err := s.storage.Delete(ctx, key); err != nil {
-			return err
+	subject := moderation.Subject{
+		ReviewID:    review.ID,
+		AuthorID:    review.AuthorID,
+		Title:       review.Title,
+		Address:     review.Address,
+		Description: review.Description,
+		Duplicates:  review.Duplicates,
+	}
+	target := models.AssessmentTargetReview
+	if review.Status == models.ReviewStatusApproved && review.PendingEdit != nil {
+		target = models.AssessmentTargetEdit
+		subject.Title = review.PendingEdit.Title
+		subject.Address = review.PendingEdit.Address
+		subject.Description = review.PendingEdit.Description
+		subject.Duplicates = review.PendingEdit.Duplicates
+	}
+
+	snapshot := *review
+	result := s.pipeline.Assess(subject)
+	assessment := &models.ReviewAssessment{
+		ReviewID:  review.ID,
+		Target:    target,
+		Score:     result.Score,
+		Decision:  result.Decision,
+		Findings:  result.Findings,
+		CreatedAt: time.Now(),
+	}
+	if err := s.reviews.SaveAssessment(assessment); err != nil {
+		log.Printf("premoderate review %s: %v", review.ID, err)
+		return
+	}
+
+	var err error
+	switch result.Decision {
+	case models.RiskDecisionApprove:
+		// Reported reviews wait for an administrator to weigh the reports.
+		var open int64
+		if open, err = s.reports.CountOpen(review.ID); err == nil && open == 0 {
+			err = s.Approve(review, workflow.ActorSystem, uuid.Nil)
 		}
+	case models.RiskDecisionReject:
+		err = s.Reject(review, workflow.ActorSystem, uuid.Nil, RejectInput{Reason: autoRejectionReason(result.Findings)})
 	}
+	if err != nil {
+		log.Printf("premoderate review %s: %s: %v", review.ID, result.Decision, err)
+		*review = snapshot
+	}
+}
+
+// autoRejectionReason summarises the findings that raised the risk score.
+func autoRejectionReason(findings []models.RiskFinding) string {
+	var reasons []string
+	for _, f := range findings {
+		if f.Score > 0 {
+			reasons = append(reasons, f.Reason)
+		}
+	}
+	return "rejected by automatic pre-moderation: " + strings.Join(reasons, "; ")
+}
+
+// duplicateCandidates bounds how many of the latest reviews by other authors
+// new content is compared against; the author's own reviews and exact copies
+// are always compared.
+const duplicateCandidates = 1000
+
+// maxDuplicateMatches bounds the matches kept for moderators.
+const maxDuplicateMatches = 5
 
+// fingerprintOf fingerprints the title and description of content. The
+// address is left out so that text copied across places still matches.
+func fingerprintOf(reviewID, authorID uuid.UUID, c reviewContent) *models.ReviewFingerprint {
+	fingerprint := similarity.Compute(c.Title + "\n" + c.Description)
+	return &models.ReviewFingerprint{
+		ReviewID: reviewID,
+		AuthorID: authorID,
+		Hash:     int64(fingerprint.Hash),
+		Digest:   fingerprint.Digest,
+		Length:   fingerprint.Length,
+	}
+}
+
+// findDuplicates returns the live reviews whose text matches the fingerprint
+// of content bound for moderation, exact copies first and then by distance.
+// With BlockExact an exact copy of one of the author's reviews is refused.
+func (s *ReviewService) findDuplicates(fingerprint *models.ReviewFingerprint) ([]models.DuplicateMatch, error) {
+	if fingerprint.Length < similarity.MinLength {
+		return nil, nil
+	}
+	candidates, err := s.reviews.SimilarityCandidates(fingerprint.ReviewID, fingerprint.AuthorID, fingerprint.Digest, duplicateCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []models.DuplicateMatch
+	for _, candidate := range candidates {
+		if candidate.Length < similarity.MinLength {
+			continue
+		}
+		exact := candidate.Digest == fingerprint.Digest
+		distance := similarity.Distance(uint64(candidate.Hash), uint64(fingerprint.Hash))
+		if !exact && distance > s.duplicates.MaxDistance {
+			continue
+		}
+		own := candidate.AuthorID == fingerprint.AuthorID
+		if exact && own && s.duplicates.BlockExact {
+			return nil, common.ErrDuplicateReview
+		}
+		matches = append(matches, models.DuplicateMatch{
+			ReviewID: candidate.ReviewID,
+			Title:    candidate.Title,
+			Status:   candidate.Status,
+			Own:      own,
+			Exact:    exact,
+			Distance: distance,
+		})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Exact != matches[j].Exact {
+			return matches[i].Exact
+		}
+		return matches[i].Distance < matches[j].Distance
+	})
+	if len(matches) > maxDuplicateMatches {
+		matches = matches[:maxDuplicateMatches]
+	}
+	return matches, nil
+}
+
+// screen runs the sensitive-word filter over content bound for moderation.
+// Blocked words reject the content, masked words are starred out in the
+// returned content and flagged words are returned for moderators.
+func (s *ReviewService) screen(c reviewContent) (reviewContent, []string, error) {
+	flags, err := screenFields(s.filter, &c.Title, &c.Address, &c.Description)
+	return c, flags, err
+}
+
+// screenFields runs filter over each field in place, starring out masked
+// words. It fails with ErrContentBlocked naming any blocked words, and
+// otherwise returns the distinct flagged words, or nil if there are none.
+func screenFields(filter *textfilter.Filter, fields ...*string) ([]string, error) {
+	var blocked []textfilter.Rule
+	var flagged []textfilter.Rule
+	for _, field := range fields {
+		result := filter.Screen(*field)
+		*field = result.Text
+		blocked = append(blocked, result.Blocked...)
+		flagged = append(flagged, result.Flagged...)
+	}
+
+	if len(blocked) > 0 {
+		words := make([]string, 0, len(blocked))
+		seen := make(map[string]bool, len(blocked))
+		for _, rule := range blocked {
+			if !seen[rule.Word] {
+				seen[rule.Word] = true
+				words = append(words, rule.Word)
+			}
+		}
+		return nil, fmt.Errorf("%w: %s", common.ErrContentBlocked, strings.Join(words, ", "))
+	}
+
+	flags := ruleWords(flagged)
+	unique := flags[:0]
+	seen := make(map[string]bool, len(flags))
+	for _, flag := range flags {
+		if !seen[flag] {
+			seen[flag] = true
+			unique = append(unique, flag)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, nil
+	}
+	return unique, nil
+}
+
+func contentOf(review *models.Review) reviewContent {
+	return reviewContent{
+		Title:       review.Title,
+		Address:     review.Address,
+		Description: review.Description,
+		Rating:      review.Rating,
+		Ratings:     review.Ratings,
+	}
+}
+
+func editContent(edit *models.ReviewEdit) reviewContent {
+	return reviewContent{
+		Title:       edit.Title,
+		Address:     edit.Address,
+		Description: edit.Description,
+		Rating:      edit.Rating,
+		Ratings:     edit.Ratings,
+	}
+}
+
+// apply returns the content with the input's changes applied, validating the
+// result. Drafts may leave the title, address and rating empty.
+func (c reviewContent) apply(input UpdateReviewInput, draft bool) (reviewContent, error) {
+	next := c
+	if input.Title != nil {
+		next.Title = strings.TrimSpace(*input.Title)
+	}
+	if input.Address != nil {
+		next.Address = strings.TrimSpace(*input.Address)
+	}
+	if input.Description != nil {
+		next.Description = strings.TrimSpace(*input.Description)
+	}
+	if !draft && (next.Title == "" || next.Address == "") {
+		return c, invalidReview("title and address are required")
+	}
+
+	rating := &c.Rating
+	if input.Ratings != nil {
+		next.Ratings = *input.Ratings
+		rating = nil
+	}
+	if input.Rating != nil {
+		rating = input.Rating
+	}
+	resolved, err := resolveRatingFor(rating, next.Ratings, draft)
+	if err != nil {
+		return c, err
+	}
+	next.Rating = resolved
+	return next, nil
+}
+
+// validateComplete checks content is ready for moderation.
+func (c reviewContent) validateComplete() error {
+	if c.Title == "" || c.Address == "" {
+		return invalidReview("title and address are required")
+	}
+	if c.Rating <= 0 && len(c.Ratings.Values()) == 0 {
+		return invalidReview("rating is required")
+	}
 	return nil
 }
 
+// diffContent lists the fields that differ between two versions of content.
+func diffContent(old, new reviewContent) []models.FieldChange {
+	var changes []models.FieldChange
+	add := func(field string, o, n interface{}) {
+		changes = append(changes, models.FieldChange{Field: field, Old: o, New: n})
+	}
+
+	if old.Title != new.Title {
+		add("title", old.Title, new.Title)
+	}
+	if old.Address != new.Address {
+		add("address", old.Address, new.Address)
+	}
+	if old.Description != new.Description {
+		add("description", old.Description, new.Description)
+	}
+	if old.Rating != new.Rating {
+		add("rating", old.Rating, new.Rating)
+	}
+
+	oldDims, newDims := old.Ratings.Values(), new.Ratings.Values()
+	for _, dim := range models.AllRatingDimensions {
+		if dim == models.RatingOverall {
+			continue
+		}
+		o, hadOld := oldDims[dim]
+		n, hasNew := newDims[dim]
+		if hadOld == hasNew && o == n {
+			continue
+		}
+		var ov, nv interface{}
+		if hadOld {
+			ov = o
+		}
+		if hasNew {
+			nv = n
+		}
+		add("ratings."+string(dim), ov, nv)
+	}
+
+	return changes
+}
+
+func (c reviewContent) applyToReview(review *models.Review) {
+	review.Title = c.Title
+	review.Address = c.Address
+	review.Description = c.Description
+	review.Rating = c.Rating
+	review.Ratings = c.Ratings
+}
+
+func (c reviewContent) applyToEdit(edit *models.ReviewEdit) {
+	edit.Title = c.Title
+	edit.Address = c.Address
+	edit.Description = c.Description
+	edit.Rating = c.Rating
+	edit.Ratings = c.Ratings
+}
+
 func sanitizeFilename(name string) string {
 	name = filepath.Base(name)
 	name = strings.ReplaceAll(name, " ", "_")
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
+	"github.com/hdu-dp/backend/internal/models"
+	"github.com/hdu-dp/backend/internal/repository"
+	"github.com/hdu-dp/backend/internal/textfilter"
+	"gorm.io/gorm"
+)
+
+// SensitiveWordService manages the sensitive word list and keeps the shared
+// content filter in sync with it.
+type SensitiveWordService struct {
+	words  *repository.SensitiveWordRepository
+	filter *textfilter.Filter
+}
+
+// NewSensitiveWordService constructs a sensitive word service instance.
+// Call Reload once to load the stored list into filter.
+func NewSensitiveWordService(words *repository.SensitiveWordRepository, filter *textfilter.Filter) *SensitiveWordService {
+	return &SensitiveWordService{words: words, filter: filter}
+}
+
+// SensitiveWordInput bundles editable word attributes.
+type SensitiveWordInput struct {
+	Word     string
+	Variants []string
+	Category string
+	Action   models.SensitiveWordAction
+}
+
+// SensitiveWordListFilters describes filters for the word list.
+type SensitiveWordListFilters struct {
+	Page     int
+	PageSize int
+	Category string
+	Action   string
+	Query    string
+}
+
+// SensitiveWordListResult wraps word list responses with pagination info.
+type SensitiveWordListResult struct {
+	Data       []models.SensitiveWord `json:"data"`
+	Pagination Pagination             `json:"pagination"`
+}
+
+// ScreenResult reports what the filter would do with a text.
+type ScreenResult struct {
+	Text    string   `json:"text"`
+	Blocked []string `json:"blocked"`
+	Flagged []string `json:"flagged"`
+}
+
+// Reload rebuilds the content filter from the stored word list.
+func (s *SensitiveWordService) Reload() error {
+	words, err := s.words.All()
+	if err != nil {
+		return err
+	}
+	rules := make([]textfilter.Rule, len(words))
+	for i, w := range words {
+		rules[i] = textfilter.Rule{
+			Word:     w.Word,
+			Variants: w.Variants,
+			Category: w.Category,
+			Action:   textfilter.Action(w.Action),
+		}
+	}
+	s.filter.Load(rules)
+	return nil
+}
+
+// List returns words matching the filters.
+func (s *SensitiveWordService) List(filters SensitiveWordListFilters) (SensitiveWordListResult, error) {
+	limit, page, offset := normalizePage(filters.Page, filters.PageSize)
+	result, err := s.words.List(repository.SensitiveWordListOptions{
+		Category: strings.TrimSpace(filters.Category),
+		Action:   models.SensitiveWordAction(strings.TrimSpace(filters.Action)),
+		Query:    strings.TrimSpace(filters.Query),
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		return SensitiveWordListResult{}, err
+	}
+	return SensitiveWordListResult{Data: result.Words, Pagination: newPagination(page, limit, result.Total)}, nil
+}
+
+// Get returns a word by ID.
+func (s *SensitiveWordService) Get(id uuid.UUID) (*models.SensitiveWord, error) {
+	return s.words.FindByID(id)
+}
+
+// Create adds a word to the list; it applies to new submissions at once.
+func (s *SensitiveWordService) Create(input SensitiveWordInput) (*models.SensitiveWord, error) {
+	input, err := normalizeSensitiveWordInput(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureWordFree(input.Word, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	word := &models.SensitiveWord{
+		ID:       uuid.New(),
+		Word:     input.Word,
+		Variants: input.Variants,
+		Category: input.Category,
+		Action:   input.Action,
+	}
+	if err := s.words.Create(word); err != nil {
+		return nil, err
+	}
+	return word, s.Reload()
+}
+
+// Update replaces the attributes of a word.
+func (s *SensitiveWordService) Update(word *models.SensitiveWord, input SensitiveWordInput) error {
+	input, err := normalizeSensitiveWordInput(input)
+	if err != nil {
+		return err
+	}
+	if err := s.ensureWordFree(input.Word, word.ID); err != nil {
+		return err
+	}
+
+	word.Word = input.Word
+	word.Variants = input.Variants
+	word.Category = input.Category
+	word.Action = input.Action
+	if err := s.words.Update(word); err != nil {
+		return err
+	}
+	return s.Reload()
+}
+
+// Delete removes a word from the list.
+func (s *SensitiveWordService) Delete(word *models.SensitiveWord) error {
+	if err := s.words.Delete(word.ID); err != nil {
+		return err
+	}
+	return s.Reload()
+}
+
+// Screen runs text through the filter without storing anything, so
+// administrators can check the effect of the list.
+func (s *SensitiveWordService) Screen(text string) ScreenResult {
+	result := s.filter.Screen(text)
+	return ScreenResult{
+		Text:    result.Text,
+		Blocked: ruleWords(result.Blocked),
+		Flagged: ruleWords(result.Flagged),
+	}
+}
+
+func (s *SensitiveWordService) ensureWordFree(text string, self uuid.UUID) error {
+	existing, err := s.words.FindByWord(text)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID != self {
+		return common.ErrSensitiveWordExists
+	}
+	return nil
+}
+
+func normalizeSensitiveWordInput(input SensitiveWordInput) (SensitiveWordInput, error) {
+	input.Word = strings.TrimSpace(input.Word)
+	input.Category = strings.ToLower(strings.TrimSpace(input.Category))
+	if input.Word == "" || utf8.RuneCountInString(input.Word) > 64 {
+		return input, errors.New("word must be 1-64 characters")
+	}
+	if input.Action == "" {
+		input.Action = models.SensitiveWordFlag
+	}
+	if !textfilter.Action(input.Action).Valid() {
+		return input, errors.New("action must be block, mask or flag")
+	}
+
+	variants := make([]string, 0, len(input.Variants))
+	seen := map[string]bool{input.Word: true}
+	for _, v := range input.Variants {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		variants = append(variants, v)
+	}
+	input.Variants = variants
+	return input, nil
+}
+
+// ruleWords lists the words of rules, labelled with their category when set.
+func ruleWords(rules []textfilter.Rule) []string {
+	words := make([]string, len(rules))
+	for i, rule := range rules {
+		words[i] = rule.Word
+		if rule.Category != "" {
+			words[i] = rule.Category + ":" + rule.Word
+		}
+	}
+	return words
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
+	"github.com/hdu-dp/backend/internal/models"
+	"github.com/hdu-dp/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// VoteService records readers' helpfulness votes and keeps the tallies on
+// reviews in step with them.
+type VoteService struct {
+	votes   *repository.ReviewVoteRepository
+	reviews *repository.ReviewRepository
+}
+
+// NewVoteService constructs a vote service instance.
+func NewVoteService(votes *repository.ReviewVoteRepository, reviews *repository.ReviewRepository) *VoteService {
+	return &VoteService{votes: votes, reviews: reviews}
+}
+
+// VoteResult is the reader's vote after a change together with the review's
+// updated tallies. Helpful is nil when the reader has no vote.
+type VoteResult struct {
+	Helpful *bool `json:"helpful"`
+	models.ReviewVoteStats
+}
+
+// Vote sets the reader's vote on a published review. Repeating the same vote
+// changes nothing and switching it replaces the earlier one, so the call is
+// idempotent. Authors cannot vote on their own reviews.
+func (s *VoteService) Vote(review *models.Review, userID uuid.UUID, helpful bool) (VoteResult, error) {
+	if review.Status != models.ReviewStatusApproved {
+		return VoteResult{}, common.ErrReviewNotVotable
+	}
+	if review.AuthorID == userID {
+		return VoteResult{}, common.ErrOwnReviewVote
+	}
+
+	var result VoteResult
+	err := s.reviews.Transaction(func(tx *gorm.DB) error {
+		votes := s.votes.WithTx(tx)
+		vote, err := votes.Find(review.ID, userID)
+		if err != nil {
+			return err
+		}
+		if vote == nil {
+			vote = &models.ReviewVote{ReviewID: review.ID, UserID: userID}
+		}
+		if vote.ID == uuid.Nil || vote.Helpful != helpful {
+			vote.Helpful = helpful
+			if err := votes.Save(vote); err != nil {
+				return err
+			}
+		}
+		result.Helpful = &helpful
+		result.ReviewVoteStats, err = s.recount(tx, review.ID)
+		return err
+	})
+	if err != nil {
+		return VoteResult{}, err
+	}
+	review.ReviewVoteStats = result.ReviewVoteStats
+	return result, nil
+}
+
+// Unvote withdraws the reader's vote on a review; withdrawing a vote that
+// does not exist changes nothing.
+func (s *VoteService) Unvote(review *models.Review, userID uuid.UUID) (VoteResult, error) {
+	var result VoteResult
+	err := s.reviews.Transaction(func(tx *gorm.DB) error {
+		if err := s.votes.WithTx(tx).Delete(review.ID, userID); err != nil {
+			return err
+		}
+		var err error
+		result.ReviewVoteStats, err = s.recount(tx, review.ID)
+		return err
+	})
+	if err != nil {
+		return VoteResult{}, err
+	}
+	review.ReviewVoteStats = result.ReviewVoteStats
+	return result, nil
+}
+
+// recount tallies the votes on a review and stores the result on it.
+func (s *VoteService) recount(tx *gorm.DB, reviewID uuid.UUID) (models.ReviewVoteStats, error) {
+	var stats models.ReviewVoteStats
+	helpful, unhelpful, err := s.votes.WithTx(tx).Tally(reviewID)
+	if err != nil {
+		return stats, err
+	}
+	stats.Reset(helpful, unhelpful)
+	return stats, s.reviews.WithTx(tx).SetVoteStats(reviewID, stats)
+}
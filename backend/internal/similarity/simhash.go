@@ -0,0 +1,86 @@
+// Package similarity fingerprints review text so that repeated and
+// near-identical content can be found without comparing texts pairwise.
+package similarity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash/fnv"
+	"math/bits"
+
+	"github.com/hdu-dp/backend/internal/textfilter"
+)
+
+const (
+	// HashBits is the size of a SimHash and so the largest possible distance.
+	HashBits = 64
+	// MinLength is the shortest canonical text, in runes, worth comparing;
+	// short texts such as "好吃" collide too easily.
+	MinLength = 8
+
+	// shingleSize is the number of runes per feature. Character bigrams work
+	// for Chinese text, which has no spaces between words.
+	shingleSize = 2
+)
+
+// Fingerprint identifies a text for duplicate detection.
+type Fingerprint struct {
+	// Hash is the SimHash of the text's character shingles; similar texts
+	// have hashes differing in few bits.
+	Hash uint64
+	// Digest is the hex SHA-256 of the canonical text, equal for exact copies.
+	Digest string
+	// Length is the number of runes in the canonical text.
+	Length int
+}
+
+// Compute fingerprints text after canonicalising it, so that copies
+// differing only in case, width, spacing or punctuation are identical.
+func Compute(text string) Fingerprint {
+	canonical := []rune(textfilter.Canonical(text))
+	digest := sha256.Sum256([]byte(string(canonical)))
+	return Fingerprint{
+		Hash:   simhash(canonical),
+		Digest: hex.EncodeToString(digest[:]),
+		Length: len(canonical),
+	}
+}
+
+// Distance returns the number of differing bits between two hashes.
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+func simhash(text []rune) uint64 {
+	var weights [HashBits]int
+	add := func(feature []rune) {
+		h := fnv.New64a()
+		h.Write([]byte(string(feature)))
+		sum := h.Sum64()
+		for i := range weights {
+			if sum&(1<<i) != 0 {
+				weights[i]++
+			} else {
+				weights[i]--
+			}
+		}
+	}
+
+	if len(text) < shingleSize {
+		if len(text) > 0 {
+			add(text)
+		}
+	} else {
+		for i := 0; i+shingleSize <= len(text); i++ {
+			add(text[i : i+shingleSize])
+		}
+	}
+
+	var hash uint64
+	for i, w := range weights {
+		if w > 0 {
+			hash |= 1 << i
+		}
+	}
+	return hash
+}
@@ -0,0 +1,113 @@
+// Package textfilter screens user content against a managed list of
+// sensitive words, tolerating the usual evasions: full-width characters,
+// case changes, separators inserted between characters and pinyin spellings.
+package textfilter
+
+import (
+	"sync"
+)
+
+// Action decides what happens to content containing a word.
+type Action string
+
+const (
+	// ActionBlock rejects the content.
+	ActionBlock Action = "block"
+	// ActionMask replaces the word with asterisks.
+	ActionMask Action = "mask"
+	// ActionFlag keeps the content but marks it for moderators.
+	ActionFlag Action = "flag"
+)
+
+// Valid reports whether a is a known action.
+func (a Action) Valid() bool {
+	switch a {
+	case ActionBlock, ActionMask, ActionFlag:
+		return true
+	}
+	return false
+}
+
+// maskRune replaces masked characters.
+const maskRune = '*'
+
+// Rule is a sensitive word together with the spellings that should be
+// treated as the same word, such as its pinyin or common abbreviations.
+type Rule struct {
+	Word     string
+	Variants []string
+	Category string
+	Action   Action
+}
+
+// Result is the outcome of screening a text.
+type Result struct {
+	// Text is the input with masked words replaced.
+	Text string
+	// Blocked and Flagged list the distinct rules found with those actions.
+	Blocked []Rule
+	Flagged []Rule
+}
+
+// Filter holds the active rule set. Rules can be replaced at any time with
+// Load; Screen always sees a complete rule set.
+type Filter struct {
+	mu      sync.RWMutex
+	rules   []Rule
+	matcher *Matcher
+}
+
+// NewFilter returns a filter without rules, which lets all text through.
+func NewFilter() *Filter {
+	return &Filter{matcher: NewMatcher(nil)}
+}
+
+// Load replaces the active rules.
+func (f *Filter) Load(rules []Rule) {
+	matcher := NewMatcher(rules)
+	f.mu.Lock()
+	f.rules, f.matcher = rules, matcher
+	f.mu.Unlock()
+}
+
+// Screen matches text against the active rules.
+func (f *Filter) Screen(text string) Result {
+	f.mu.RLock()
+	rules, matcher := f.rules, f.matcher
+	f.mu.RUnlock()
+
+	result := Result{Text: text}
+	matches := matcher.FindAll(text)
+	if len(matches) == 0 {
+		return result
+	}
+
+	var masked []rune
+	seen := make(map[int]bool)
+	for _, match := range matches {
+		rule := rules[match.Rule]
+		if rule.Action == ActionMask {
+			if masked == nil {
+				masked = []rune(text)
+			}
+			for i := match.Start; i < match.End; i++ {
+				masked[i] = maskRune
+			}
+			continue
+		}
+		if seen[match.Rule] {
+			continue
+		}
+		seen[match.Rule] = true
+		switch rule.Action {
+		case ActionBlock:
+			result.Blocked = append(result.Blocked, rule)
+		case ActionFlag:
+			result.Flagged = append(result.Flagged, rule)
+		}
+	}
+	if masked != nil {
+		result.Text = string(masked)
+	}
+	return result
+}
@@ -0,0 +1,133 @@
+package textfilter
+
+// Matcher finds rule patterns in text with an Aho–Corasick automaton built
+// over folded runes, so a single pass finds every occurrence of every
+// pattern. A Matcher is immutable and safe for concurrent use.
+type Matcher struct {
+	nodes []node
+}
+
+type node struct {
+	next map[rune]int
+	fail int
+	// outputs lists the patterns ending at this node, including those
+	// reached through failure links.
+	outputs []pattern
+}
+
+type pattern struct {
+	rule   int
+	length int
+	// ascii patterns only match whole latin words, so that "shit" is not
+	// found in "has hit" once separators are dropped.
+	ascii bool
+}
+
+// Match is an occurrence of a rule in the original text; Start and End are
+// rune offsets, End exclusive.
+type Match struct {
+	Rule  int
+	Start int
+	End   int
+}
+
+// NewMatcher compiles the word and variants of each rule. Match.Rule indexes
+// into rules.
+func NewMatcher(rules []Rule) *Matcher {
+	m := &Matcher{nodes: []node{{next: map[rune]int{}}}}
+	for i, rule := range rules {
+		for _, word := range append([]string{rule.Word}, rule.Variants...) {
+			m.add(i, word)
+		}
+	}
+	m.link()
+	return m
+}
+
+func (m *Matcher) add(rule int, word string) {
+	folded, _ := normalize([]rune(word))
+	if len(folded) == 0 {
+		return
+	}
+
+	ascii := true
+	cur := 0
+	for _, r := range folded {
+		if r >= 0x80 {
+			ascii = false
+		}
+		nxt, ok := m.nodes[cur].next[r]
+		if !ok {
+			nxt = len(m.nodes)
+			m.nodes = append(m.nodes, node{next: map[rune]int{}})
+			m.nodes[cur].next[r] = nxt
+		}
+		cur = nxt
+	}
+	m.nodes[cur].outputs = append(m.nodes[cur].outputs, pattern{rule: rule, length: len(folded), ascii: ascii})
+}
+
+// link computes failure links breadth first and merges the outputs of each
+// node's failure target into its own.
+func (m *Matcher) link() {
+	queue := make([]int, 0, len(m.nodes))
+	for _, child := range m.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for r, child := range m.nodes[cur].next {
+			fail := m.nodes[cur].fail
+			for fail > 0 {
+				if _, ok := m.nodes[fail].next[r]; ok {
+					break
+				}
+				fail = m.nodes[fail].fail
+			}
+			if target, ok := m.nodes[fail].next[r]; ok && target != child {
+				m.nodes[child].fail = target
+			}
+			m.nodes[child].outputs = append(m.nodes[child].outputs, m.nodes[m.nodes[child].fail].outputs...)
+			queue = append(queue, child)
+		}
+	}
+}
+
+// FindAll returns every match in text, ordered by end position.
+func (m *Matcher) FindAll(text string) []Match {
+	original := []rune(text)
+	folded, offsets := normalize(original)
+
+	var matches []Match
+	cur := 0
+	for i, r := range folded {
+		for cur > 0 {
+			if _, ok := m.nodes[cur].next[r]; ok {
+				break
+			}
+			cur = m.nodes[cur].fail
+		}
+		if nxt, ok := m.nodes[cur].next[r]; ok {
+			cur = nxt
+		}
+		for _, p := range m.nodes[cur].outputs {
+			start := offsets[i-p.length+1]
+			end := offsets[i] + 1
+			if p.ascii && !wordBounded(original, start, end) {
+				continue
+			}
+			matches = append(matches, Match{Rule: p.rule, Start: start, End: end})
+		}
+	}
+	return matches
+}
+
+// wordBounded reports whether original[start:end] is not glued to latin
+// letters on either side.
+func wordBounded(original []rune, start, end int) bool {
+	if start > 0 && isASCIILetter(original[start-1]) {
+		return false
+	}
+	return end >= len(original) || !isASCIILetter(original[end])
+}
@@ -0,0 +1,84 @@
+package textfilter
+
+import (
+	"strings"
+	"unicode"
+)
+
+// toneFold maps pinyin vowels with tone marks to their plain letters so that
+// "wēixìn" matches the variant "weixin".
+var toneFold = map[rune]rune{
+	'ā': 'a', 'á': 'a', 'ǎ': 'a', 'à': 'a',
+	'ē': 'e', 'é': 'e', 'ě': 'e', 'è': 'e',
+	'ī': 'i', 'í': 'i', 'ǐ': 'i', 'ì': 'i',
+	'ō': 'o', 'ó': 'o', 'ǒ': 'o', 'ò': 'o',
+	'ū': 'u', 'ú': 'u', 'ǔ': 'u', 'ù': 'u',
+	'ǖ': 'v', 'ǘ': 'v', 'ǚ': 'v', 'ǜ': 'v', 'ü': 'v',
+}
+
+// foldRune canonicalises a rune for matching: full-width forms become their
+// half-width equivalents, letters are lower-cased and pinyin tone marks are
+// dropped. It reports false for runes that carry no meaning for matching,
+// such as spaces, punctuation and symbols inserted to split a word.
+func foldRune(r rune) (rune, bool) {
+	r = unicode.ToLower(halfWidth(r))
+	if folded, ok := toneFold[r]; ok {
+		r = folded
+	}
+	if !unicode.IsLetter(r) && !unicode.IsNumber(r) {
+		return 0, false
+	}
+	return r, true
+}
+
+// normalize folds text for matching and returns, for every kept rune, its
+// index in the original rune slice.
+func normalize(text []rune) ([]rune, []int) {
+	folded := make([]rune, 0, len(text))
+	offsets := make([]int, 0, len(text))
+	for i, r := range text {
+		if f, ok := foldRune(r); ok {
+			folded = append(folded, f)
+			offsets = append(offsets, i)
+		}
+	}
+	return folded, offsets
+}
+
+// Canonical folds text as for matching and keeps only letters and digits, so
+// that texts differing only in case, width, tone marks, spacing or
+// punctuation compare equal.
+func Canonical(text string) string {
+	folded, _ := normalize([]rune(text))
+	return string(folded)
+}
+
+// isASCIILetter reports whether r is an ASCII letter after folding.
+func isASCIILetter(r rune) bool {
+	f, ok := foldRune(r)
+	return ok && f < unicode.MaxASCII && unicode.IsLetter(f)
+}
+
+// Fold converts full-width characters to half-width and lower-cases text,
+// keeping separators, so that patterns written for ASCII also match
+// full-width input.
+func Fold(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+	for _, r := range text {
+		b.WriteRune(unicode.ToLower(halfWidth(r)))
+	}
+	return b.String()
+}
+
+// halfWidth maps full-width ASCII variants and the ideographic space to
+// their ASCII equivalents.
+func halfWidth(r rune) rune {
+	switch {
+	case r == '　':
+		return ' '
+	case r >= '！' && r <= '～':
+		return r - 0xfee0
+	}
+	return r
+}
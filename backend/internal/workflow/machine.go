@@ -0,0 +1,166 @@
+// Package workflow declares the review lifecycle: which status transitions
+// exist, who may perform them, and the events emitted when they happen.
+package workflow
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hdu-dp/backend/internal/common"
+	"github.com/hdu-dp/backend/internal/models"
+)
+
+// Action names a step in the review lifecycle.
+type Action string
+
+// Actor is the kind of party performing an action.
+type Actor string
+
+const (
+	ActorAuthor Actor = "author"
+	ActorAdmin  Actor = "admin"
+	ActorSystem Actor = "system"
+)
+
+// Transition declares that Action moves a review from any of From to To and
+// may be performed by the listed actors.
+type Transition struct {
+	Action Action
+	From   []models.ReviewStatus
+	To     models.ReviewStatus
+	Actors []Actor
+	// Conflict is returned when the review is not in a From status; it
+	// defaults to common.ErrInvalidTransition.
+	Conflict error
+}
+
+func (t Transition) allowsFrom(status models.ReviewStatus) bool {
+	for _, from := range t.From {
+		if from == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (t Transition) allowsActor(actor Actor) bool {
+	for _, a := range t.Actors {
+		if a == actor {
+			return true
+		}
+	}
+	return false
+}
+
+// Event describes a transition that has been applied to a review.
+type Event struct {
+	Action   Action              `json:"action"`
+	ReviewID uuid.UUID           `json:"review_id"`
+	AuthorID uuid.UUID           `json:"author_id"`
+	From     models.ReviewStatus `json:"from"`
+	To       models.ReviewStatus `json:"to"`
+	Actor    Actor               `json:"actor"`
+	// ActorID is uuid.Nil for system actions.
+	ActorID uuid.UUID `json:"actor_id"`
+	Reason  string    `json:"reason,omitempty"`
+	At      time.Time `json:"at"`
+}
+
+// Listener is notified of applied transitions. Listeners run synchronously
+// after the change is committed and should return quickly.
+type Listener func(Event)
+
+// Machine validates and applies review status transitions.
+type Machine struct {
+	transitions map[Action]Transition
+
+	mu        sync.RWMutex
+	listeners []Listener
+}
+
+// NewMachine constructs a machine from transition declarations. Declaring the
+// same action twice replaces the earlier declaration.
+func NewMachine(transitions ...Transition) *Machine {
+	m := &Machine{transitions: make(map[Action]Transition, len(transitions))}
+	for _, t := range transitions {
+		m.transitions[t.Action] = t
+	}
+	return m
+}
+
+// Check reports whether actor may perform action on a review in status from.
+func (m *Machine) Check(action Action, from models.ReviewStatus, actor Actor) error {
+	t, ok := m.transitions[action]
+	if !ok {
+		return common.ErrInvalidTransition
+	}
+	if !t.allowsActor(actor) {
+		return common.ErrTransitionForbidden
+	}
+	if !t.allowsFrom(from) {
+		if t.Conflict != nil {
+			return t.Conflict
+		}
+		return common.ErrInvalidTransition
+	}
+	return nil
+}
+
+// Apply validates the transition and moves the review to its target status.
+// The returned event should be published once the change is persisted.
+func (m *Machine) Apply(review *models.Review, action Action, actor Actor, actorID uuid.UUID, reason string) (Event, error) {
+	if err := m.Check(action, review.Status, actor); err != nil {
+		return Event{}, err
+	}
+
+	event := Event{
+		Action:   action,
+		ReviewID: review.ID,
+		AuthorID: review.AuthorID,
+		From:     review.Status,
+		To:       m.transitions[action].To,
+		Actor:    actor,
+		ActorID:  actorID,
+		Reason:   reason,
+		At:       time.Now(),
+	}
+	review.Status = event.To
+	return event, nil
+}
+
+// NewEvent describes an action that does not change the review's status,
+// such as moving it to the trash.
+func NewEvent(review *models.Review, action Action, actor Actor, actorID uuid.UUID, reason string) Event {
+	return Event{
+		Action:   action,
+		ReviewID: review.ID,
+		AuthorID: review.AuthorID,
+		From:     review.Status,
+		To:       review.Status,
+		Actor:    actor,
+		ActorID:  actorID,
+		Reason:   reason,
+		At:       time.Now(),
+	}
+}
+
+// Subscribe registers a listener for published events.
+func (m *Machine) Subscribe(listener Listener) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.listeners = append(m.listeners, listener)
+}
+
+// Publish delivers events to all listeners in subscription order.
+func (m *Machine) Publish(events ...Event) {
+	m.mu.RLock()
+	listeners := append([]Listener(nil), m.listeners...)
+	m.mu.RUnlock()
+
+	for _, event := range events {
+		for _, listener := range listeners {
+			listener(event)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package workflow
+
+import (
+	"github.com/hdu-dp/backend/internal/common"
+	"github.com/hdu-dp/backend/internal/models"
+)
+
+const (
+	// ActionSubmit sends a draft or withdrawn review to moderation.
+	ActionSubmit Action = "submit"
+	// ActionEdit changes a review that is not yet public; it re-enters moderation.
+	ActionEdit Action = "edit"
+	// ActionProposeEdit stores an edit of an approved review for moderation.
+	ActionProposeEdit Action = "propose_edit"
+	// ActionWithdraw pulls a pending review out of moderation.
+	ActionWithdraw Action = "withdraw"
+	// ActionWithdrawEdit discards the author's pending edit of an approved review.
+	ActionWithdrawEdit Action = "withdraw_edit"
+	ActionApprove      Action = "approve"
+	ActionReject       Action = "reject"
+	// ActionApproveEdit replaces approved content with its pending edit.
+	ActionApproveEdit Action = "approve_edit"
+	// ActionRejectEdit discards the pending edit of an approved review.
+	ActionRejectEdit Action = "reject_edit"
+	// ActionAppeal asks administrators to reconsider a rejection.
+	ActionAppeal Action = "appeal"
+	// ActionAcceptAppeal overturns the rejection and publishes the review.
+	ActionAcceptAppeal Action = "accept_appeal"
+	// ActionDenyAppeal upholds the rejection.
+	ActionDenyAppeal Action = "deny_appeal"
+	// ActionRequeue sends a published review back to moderation after
+	// readers reported it.
+	ActionRequeue Action = "requeue"
+
+	// ActionDelete and ActionRestore move a review in and out of the trash.
+	// They leave the status untouched and are not declared as transitions.
+	ActionDelete  Action = "delete"
+	ActionRestore Action = "restore"
+)
+
+// ReviewTransitions declares the review lifecycle:
+//
+//	                   ┌─────requeue──────┐
+//	                   ▼                  │
+//	draft ─submit─▶ pending ─approve─▶ approved ◀─accept_appeal─┐
+//	                  │ ▲  └─reject──▶ rejected ─appeal─▶ appealed
+//	         withdraw │ │ submit/edit     │  ▲                │
+//	                  ▼ │          edit ◀─┘  └──deny_appeal───┘
+//	               withdrawn
+//
+// Edits of an approved review keep it approved while the edit is moderated.
+var ReviewTransitions = []Transition{
+	{
+		Action:   ActionSubmit,
+		From:     []models.ReviewStatus{models.ReviewStatusDraft, models.ReviewStatusWithdrawn},
+		To:       models.ReviewStatusPending,
+		Actors:   []Actor{ActorAuthor},
+		Conflict: common.ErrReviewNotSubmittable,
+	},
+	{
+		Action:   ActionEdit,
+		From:     []models.ReviewStatus{models.ReviewStatusPending, models.ReviewStatusRejected, models.ReviewStatusWithdrawn},
+		To:       models.ReviewStatusPending,
+		Actors:   []Actor{ActorAuthor},
+		Conflict: common.ErrReviewAlreadyProcessed,
+	},
+	{
+		Action:   ActionProposeEdit,
+		From:     []models.ReviewStatus{models.ReviewStatusApproved},
+		To:       models.ReviewStatusApproved,
+		Actors:   []Actor{ActorAuthor},
+		Conflict: common.ErrReviewAlreadyProcessed,
+	},
+	{
+		Action:   ActionWithdraw,
+		From:     []models.ReviewStatus{models.ReviewStatusPending},
+		To:       models.ReviewStatusWithdrawn,
+		Actors:   []Actor{ActorAuthor},
+		Conflict: common.ErrReviewNotWithdrawable,
+	},
+	{
+		Action:   ActionWithdrawEdit,
+		From:     []models.ReviewStatus{models.ReviewStatusApproved},
+		To:       models.ReviewStatusApproved,
+		Actors:   []Actor{ActorAuthor},
+		Conflict: common.ErrReviewNotWithdrawable,
+	},
+	{
+		Action:   ActionApprove,
+		From:     []models.ReviewStatus{models.ReviewStatusPending},
+		To:       models.ReviewStatusApproved,
+		Actors:   []Actor{ActorAdmin, ActorSystem},
+		Conflict: common.ErrReviewAlreadyProcessed,
+	},
+	{
+		Action:   ActionReject,
+		From:     []models.ReviewStatus{models.ReviewStatusPending},
+		To:       models.ReviewStatusRejected,
+		Actors:   []Actor{ActorAdmin, ActorSystem},
+		Conflict: common.ErrReviewAlreadyProcessed,
+	},
+	{
+		Action:   ActionApproveEdit,
+		From:     []models.ReviewStatus{models.ReviewStatusApproved},
+		To:       models.ReviewStatusApproved,
+		Actors:   []Actor{ActorAdmin, ActorSystem},
+		Conflict: common.ErrReviewAlreadyProcessed,
+	},
+	{
+		Action:   ActionRejectEdit,
+		From:     []models.ReviewStatus{models.ReviewStatusApproved},
+		To:       models.ReviewStatusApproved,
+		Actors:   []Actor{ActorAdmin, ActorSystem},
+		Conflict: common.ErrReviewAlreadyProcessed,
+	},
+	{
+		Action:   ActionRequeue,
+		From:     []models.ReviewStatus{models.ReviewStatusApproved},
+		To:       models.ReviewStatusPending,
+		Actors:   []Actor{ActorSystem},
+		Conflict: common.ErrReviewAlreadyProcessed,
+	},
+	{
+		Action:   ActionAppeal,
+		From:     []models.ReviewStatus{models.ReviewStatusRejected},
+		To:       models.ReviewStatusAppealed,
+		Actors:   []Actor{ActorAuthor},
+		Conflict: common.ErrReviewNotAppealable,
+	},
+	{
+		Action:   ActionAcceptAppeal,
+		From:     []models.ReviewStatus{models.ReviewStatusAppealed},
+		To:       models.ReviewStatusApproved,
+		Actors:   []Actor{ActorAdmin},
+		Conflict: common.ErrReviewAlreadyProcessed,
+	},
+	{
+		Action:   ActionDenyAppeal,
+		From:     []models.ReviewStatus{models.ReviewStatusAppealed},
+		To:       models.ReviewStatusRejected,
+		Actors:   []Actor{ActorAdmin},
+		Conflict: common.ErrReviewAlreadyProcessed,
+	},
+}
+
+// NewReviewMachine returns a machine enforcing ReviewTransitions.
+func NewReviewMachine() *Machine {
+	return NewMachine(ReviewTransitions...)
+}